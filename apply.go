@@ -0,0 +1,171 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type applyOpts struct {
+	filterOpts
+	changed *[]string // the fields changed by Apply
+}
+
+// UseChangeReport makes Apply append the names of the fields it changed to changed.
+// Fields of nested structs are named by their paths joined with dots, e.g. address.city
+func UseChangeReport(changed *[]string) optFunc[applyOpts] {
+	return func(o *applyOpts) {
+		o.changed = changed
+	}
+}
+
+// Apply copies the set fields of patch onto dst, which must be a pointer to a struct.
+// patch can be a struct or a pointer to one, usually with nullable fields.
+// Fields are matched by their tags, which can be changed with the UseTag option,
+// or by their names if there is no field with the same tag.
+//
+// Unset fields and nil pointers are skipped. NULL values set nullable, pointer, map, slice
+// and interface fields to NULL or nil, while NULL into any other field is an error.
+// Values are converted with the same rules as Scan if needed. Filterable structs are applied
+// recursively. The changed fields can be reported with the UseChangeReport option.
+func Apply(dst, patch any, opts ...option) error {
+	dv, err := scanDest(dst)
+	if err != nil {
+		return err
+	}
+
+	if patch == nil {
+		return errors.New("patch cannot be nil")
+	}
+
+	pv := reflect.Indirect(reflect.ValueOf(patch))
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("invalid type %T. patch must be a struct", patch)
+	}
+
+	// set options
+	fOpts, err := newOpts(applyOpts{filterOpts: defaultFilterOpts}, opts)
+	if err != nil {
+		return err
+	}
+
+	return applyStruct(fOpts, dv, pv, "")
+}
+
+// applyStruct copies the set fields of the patch struct onto dst
+func applyStruct(fOpts applyOpts, dst, patch reflect.Value, prefix string) error {
+	dstFields := fieldsByName(fOpts.tag, dst)
+
+	for i := 0; i < patch.NumField(); i++ {
+		field := patch.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get(fOpts.tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		// promoted fields are applied on the same level
+		if field.Anonymous && name == "" {
+			if _, ok := patch.Field(i).Interface().(Filterable); ok && field.Type.Kind() == reflect.Struct {
+				if err := applyStruct(fOpts, dst, patch.Field(i), prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		d, ok := dstFields[name]
+		if !ok {
+			if d, ok = fieldByName(dst, field.Name); !ok {
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if err := applyField(fOpts, d, patch.Field(i), prefix+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldByName returns the settable field of dst with the given name, including the promoted ones.
+// Fields promoted through nil pointers to embedded structs are not returned
+func fieldByName(dst reflect.Value, name string) (reflect.Value, bool) {
+	field, ok := dst.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	d, err := dst.FieldByIndexErr(field.Index)
+	if err != nil || !d.CanSet() {
+		return reflect.Value{}, false
+	}
+
+	return d, true
+}
+
+// applyField copies the patch value onto the dst field if it is set
+func applyField(fOpts applyOpts, dst, patch reflect.Value, path string) error {
+	var src any
+
+	switch val := patch.Interface().(type) {
+	case nullVar:
+		if !val.isSet() {
+			return nil
+		}
+
+		src = val.getVal()
+		if src == nil && !isNullable(dst) {
+			return fmt.Errorf("%s: cannot assign NULL to non-nullable type %s", path, dst.Type())
+		}
+	case Filterable:
+		if _, dstIsVar := dst.Addr().Interface().(nullVarPtr); patch.Kind() == reflect.Struct && dst.Kind() == reflect.Struct && !dstIsVar {
+			return applyStruct(fOpts, dst, patch, path+".")
+		}
+		src = val
+	default:
+		if patch.Kind() == reflect.Pointer {
+			if patch.IsNil() {
+				return nil
+			}
+			patch = patch.Elem()
+		}
+		src = patch.Interface()
+	}
+
+	before := reflect.New(dst.Type()).Elem()
+	before.Set(dst)
+
+	if err := assignValue(dst, src); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if fOpts.changed != nil && !valuesEqual(before, dst) {
+		*fOpts.changed = append(*fOpts.changed, path)
+	}
+
+	return nil
+}
+
+// isNullable returns if the given field can hold NULL
+func isNullable(v reflect.Value) bool {
+	if _, ok := v.Addr().Interface().(nullVarPtr); ok {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return true
+	}
+
+	return false
+}
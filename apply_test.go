@@ -0,0 +1,161 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string `json:"zip"`
+	}
+
+	type User struct {
+		ID       int64
+		Name     string            `json:"name"`
+		Nickname *string           `json:"nickname"`
+		Age      int               `json:"age"`
+		Score    float64           `json:"score"`
+		Email    Var[string]       `json:"email"`
+		Tags     []string          `json:"tags"`
+		Created  time.Time         `json:"created"`
+		Address  Address           `json:"address"`
+		Meta     map[string]string `json:"meta"`
+	}
+
+	type AddressPatch struct {
+		Filterable
+
+		City Var[string]
+		Zip  Var[string] `json:"zip"`
+	}
+
+	type Common struct {
+		Filterable
+
+		Age Var[int] `json:"age"`
+	}
+
+	type UserPatch struct {
+		Filterable
+		Common
+
+		Name     Var[string]       `json:"name"`
+		Nickname Var[string]       `json:"nickname"`
+		Score    Var[string]       `json:"score"`
+		Email    Var[string]       `json:"email"`
+		Tags     Var[[]string]     `json:"tags"`
+		Created  Var[string]       `json:"created"`
+		Address  AddressPatch      `json:"address"`
+		Meta     Var[int]          `json:"-"`
+		Unknown  Var[string]       `json:"unknown"`
+		ID       *int64            `json:"-"`
+		Other    map[string]string `json:"meta"`
+	}
+
+	nick := "nick"
+	u := User{
+		ID:       1,
+		Name:     "foo",
+		Nickname: &nick,
+		Age:      30,
+		Email:    From("foo@example.com"),
+		Tags:     []string{"a"},
+		Address:  Address{City: "Budapest", Zip: "1000"},
+	}
+
+	var changed []string
+	err := Apply(&u, UserPatch{
+		Common:   Common{Age: From(31)},
+		Name:     From("foo"),
+		Nickname: Null[string](),
+		Score:    From("1.5"),
+		Email:    Null[string](),
+		Tags:     Null[[]string](),
+		Created:  From("2023-01-02T03:04:05Z"),
+		Address:  AddressPatch{City: From("Szeged")},
+		Unknown:  From("unknown"),
+	}, UseChangeReport(&changed))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, u.ID, 1)
+	assertEqualTerminateTest(t, u.Name, "foo")
+	assertEqualTerminateTest(t, u.Nickname, nil)
+	assertEqualTerminateTest(t, u.Age, 31)
+	assertEqualTerminateTest(t, u.Score, 1.5)
+	assertEqualTerminateTest(t, u.Email, Null[string]())
+	assertEqualTerminateTest(t, u.Tags == nil, true)
+	assertEqualTerminateTest(t, u.Created.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), true)
+	assertEqualTerminateTest(t, u.Address.City, "Szeged")
+	assertEqualTerminateTest(t, u.Address.Zip, "1000")
+	assertEqualTerminateTest(t, u.Meta == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", changed), "[age nickname score email tags created address.City]")
+
+	// plain fields are always copied, nil pointers are skipped
+	changed = nil
+	id := int64(2)
+	err = Apply(&u, &UserPatch{ID: &id, Other: map[string]string{"a": "b"}}, UseChangeReport(&changed))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, u.ID, 1)
+	assertEqualTerminateTest(t, u.Meta["a"], "b")
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", changed), "[meta]")
+
+	// without the tags the fields are matched by their names
+	changed = nil
+	err = Apply(&u, &UserPatch{ID: &id}, UseChangeReport(&changed), UseTag("db"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, u.ID, 2)
+	assertEqualTerminateTest(t, u.Meta["a"], "b")
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", changed), "[ID]")
+
+	err = Apply(&u, UserPatch{Name: Null[string]()})
+	assertEqualTerminateTest(t, err.Error(), "name: cannot assign NULL to non-nullable type string")
+
+	err = Apply(&u, UserPatch{Address: AddressPatch{Zip: Null[string]()}})
+	assertEqualTerminateTest(t, err.Error(), "address.zip: cannot assign NULL to non-nullable type string")
+
+	err = Apply(&u, UserPatch{Score: From("foo")})
+	assertEqualTerminateTest(t, err.Error(), `score: assigning string to float64: converting driver.Value type string ("foo") to a float64: invalid syntax`)
+
+	err = Apply(u, UserPatch{})
+	assertEqualTerminateTest(t, err.Error(), "invalid type null.User. destination must be a non-nil pointer to a struct")
+
+	err = Apply(&u, nil)
+	assertEqualTerminateTest(t, err.Error(), "patch cannot be nil")
+
+	err = Apply(&u, 1)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. patch must be a struct")
+
+	err = Apply(&u, UserPatch{}, UseTag("db"), UseNullValues())
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+
+	// fields promoted through nil pointers are skipped
+	type Model struct {
+		Name string
+		Age  int
+	}
+
+	type Entity struct {
+		*Model
+		ID int64
+	}
+
+	type EntityPatch struct {
+		Name Var[string]
+		ID   Var[int64]
+	}
+
+	var e Entity
+	changed = nil
+	err = Apply(&e, EntityPatch{Name: From("foo"), ID: From(int64(1))}, UseChangeReport(&changed))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, e.Model == nil, true)
+	assertEqualTerminateTest(t, e.ID, 1)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", changed), "[ID]")
+
+	e.Model = &Model{Age: 1}
+	err = Apply(&e, EntityPatch{Name: From("foo")})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, *e.Model, Model{Name: "foo", Age: 1})
+}
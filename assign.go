@@ -0,0 +1,93 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// assignValue assigns src to dst, converting it if necessary.
+// Nullable variables in src are unwrapped and a nil src sets nullable
+// destinations to NULL and every other destination to its zero value.
+// dst must be settable.
+func assignValue(dst reflect.Value, src any) error {
+	if nv, ok := src.(nullVar); ok {
+		src = nv.getVal()
+	}
+
+	if np, ok := dst.Addr().Interface().(nullVarPtr); ok {
+		return np.assign(src)
+	}
+
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer && sv.Type().AssignableTo(dst.Type().Elem()) {
+		p := reflect.New(dst.Type().Elem())
+		p.Elem().Set(sv)
+		dst.Set(p)
+		return nil
+	}
+
+	if err := convertAssign(dst.Addr().Interface(), src); err != nil {
+		return fmt.Errorf("assigning %T to %s: %w", src, dst.Type(), err)
+	}
+
+	return nil
+}
+
+// fieldsByName returns the fields of the given struct by their tag names.
+// The fields of embedded Filterable structs without a tag name are promoted
+// to the same level, unless a field with the same name already exists.
+func fieldsByName(tag string, val reflect.Value) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	for _, f := range flatFields(tag, val) {
+		fields[f.name] = f.value
+	}
+
+	return fields
+}
+
+// flatFields returns the fields of the given struct in declaration order.
+// The fields of embedded Filterable structs without a tag name are promoted
+// to the position of the embedded struct, unless a field with the same name
+// already exists.
+func flatFields(tag string, val reflect.Value) []taggedField {
+	fields := structFields(tag, val)
+
+	names := make(map[string]bool)
+	for _, f := range fields {
+		if !f.embedded || f.name != "" {
+			names[f.name] = true
+		}
+	}
+
+	flat := []taggedField{}
+	for _, f := range fields {
+		if !f.embedded || f.name != "" {
+			flat = append(flat, f)
+			continue
+		}
+
+		if _, ok := f.value.Interface().(Filterable); !ok {
+			continue
+		}
+
+		for _, pf := range flatFields(tag, f.value) {
+			if names[pf.name] {
+				continue
+			}
+			names[pf.name] = true
+			flat = append(flat, pf)
+		}
+	}
+
+	return flat
+}
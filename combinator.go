@@ -0,0 +1,76 @@
+package null
+
+// Map applies f to the value of v and returns the result as a new Var.
+// Unset and NULL states are carried over without calling f.
+func Map[T, U any](v Var[T], f func(T) U) Var[U] {
+	var r Var[U]
+	switch v.state {
+	case StateNull:
+		r.SetNil()
+	case StateValue:
+		r.Set(f(v.value))
+	}
+
+	return r
+}
+
+// FlatMap applies f to the value of v and returns its result.
+// Unset and NULL states are carried over without calling f.
+func FlatMap[T, U any](v Var[T], f func(T) Var[U]) Var[U] {
+	var r Var[U]
+	switch v.state {
+	case StateNull:
+		r.SetNil()
+	case StateValue:
+		r = f(v.value)
+	}
+
+	return r
+}
+
+// Filter returns v if it holds a value that satisfies pred.
+// A value that does not satisfy pred becomes NULL, so the result is still set.
+// Unset and NULL states are carried over without calling pred.
+func Filter[T any](v Var[T], pred func(T) bool) Var[T] {
+	if v.state == StateValue && !pred(v.value) {
+		v.SetNil()
+	}
+
+	return v
+}
+
+// ValueOr returns the value of v or def if v is unset or NULL
+func ValueOr[T any](v Var[T], def T) T {
+	if v.state != StateValue {
+		return def
+	}
+
+	return v.value
+}
+
+// ValueOrElse returns the value of v or the result of f if v is unset or NULL.
+// f is only called when it is needed.
+func ValueOrElse[T any](v Var[T], f func() T) T {
+	if v.state != StateValue {
+		return f()
+	}
+
+	return v.value
+}
+
+// Coalesce returns the first Var that holds a value.
+// If none of them holds a value, then the result is NULL if any of them were set
+// and unset otherwise, similarly to the SQL COALESCE function.
+func Coalesce[T any](vs ...Var[T]) Var[T] {
+	var r Var[T]
+	for _, v := range vs {
+		switch v.state {
+		case StateNull:
+			r.SetNil()
+		case StateValue:
+			return v
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,131 @@
+package null
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	f := func(i int) string {
+		return strconv.Itoa(i * 2)
+	}
+
+	var v Var[int]
+	if err := checkVar(t, Map(v, f), false, false, ""); err != nil {
+		t.Errorf("[unset] %v", err)
+	}
+
+	v.SetNil()
+	if err := checkVar(t, Map(v, f), true, false, ""); err != nil {
+		t.Errorf("[nil] %v", err)
+	}
+
+	v.Set(21)
+	if err := checkVar(t, Map(v, f), true, true, "42"); err != nil {
+		t.Errorf("[value] %v", err)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	f := func(s string) Var[int] {
+		var r Var[int]
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			r.SetNil()
+			return r
+		}
+		r.Set(i)
+		return r
+	}
+
+	var v Var[string]
+	if err := checkVar(t, FlatMap(v, f), false, false, 0); err != nil {
+		t.Errorf("[unset] %v", err)
+	}
+
+	v.SetNil()
+	if err := checkVar(t, FlatMap(v, f), true, false, 0); err != nil {
+		t.Errorf("[nil] %v", err)
+	}
+
+	v.Set("foo")
+	if err := checkVar(t, FlatMap(v, f), true, false, 0); err != nil {
+		t.Errorf("[invalid value] %v", err)
+	}
+
+	v.Set("42")
+	if err := checkVar(t, FlatMap(v, f), true, true, 42); err != nil {
+		t.Errorf("[value] %v", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(i int) bool {
+		return i%2 == 0
+	}
+
+	var v Var[int]
+	if err := checkVar(t, Filter(v, even), false, false, 0); err != nil {
+		t.Errorf("[unset] %v", err)
+	}
+
+	v.SetNil()
+	if err := checkVar(t, Filter(v, even), true, false, 0); err != nil {
+		t.Errorf("[nil] %v", err)
+	}
+
+	v.Set(1)
+	if err := checkVar(t, Filter(v, even), true, false, 0); err != nil {
+		t.Errorf("[filtered] %v", err)
+	}
+
+	v.Set(2)
+	if err := checkVar(t, Filter(v, even), true, true, 2); err != nil {
+		t.Errorf("[value] %v", err)
+	}
+}
+
+func TestValueOr(t *testing.T) {
+	called := 0
+	f := func() string {
+		called++
+		return "default"
+	}
+
+	var v Var[string]
+	assertEqualTerminateTest(t, ValueOr(v, "default"), "default")
+	assertEqualTerminateTest(t, ValueOrElse(v, f), "default")
+
+	v.SetNil()
+	assertEqualTerminateTest(t, ValueOr(v, "default"), "default")
+	assertEqualTerminateTest(t, ValueOrElse(v, f), "default")
+
+	v.Set("foo")
+	assertEqualTerminateTest(t, ValueOr(v, "default"), "foo")
+	assertEqualTerminateTest(t, ValueOrElse(v, f), "foo")
+
+	assertEqualTerminateTest(t, called, 2)
+}
+
+func TestCoalesce(t *testing.T) {
+	var unset, nul, a, b Var[string]
+	nul.SetNil()
+	a.Set("a")
+	b.Set("b")
+
+	if err := checkVar(t, Coalesce[string](), false, false, ""); err != nil {
+		t.Errorf("[empty] %v", err)
+	}
+
+	if err := checkVar(t, Coalesce(unset, unset), false, false, ""); err != nil {
+		t.Errorf("[unset] %v", err)
+	}
+
+	if err := checkVar(t, Coalesce(unset, nul, unset), true, false, ""); err != nil {
+		t.Errorf("[nil] %v", err)
+	}
+
+	if err := checkVar(t, Coalesce(unset, nul, a, b), true, true, "a"); err != nil {
+		t.Errorf("[value] %v", err)
+	}
+}
@@ -17,6 +17,16 @@ var errNilPtr = errors.New("destination pointer is nil")
 // dest should be a pointer type. This is a stripped down version of the
 // standard database/sql package's convertAssignRows function.
 func convertAssign(dest, src any) error {
+	return convertAssignWith(dest, src, nil)
+}
+
+// convertAssignWith is the same as convertAssign, but it also consults the given
+// scoped converters and then the registered ones before any other conversion.
+func convertAssignWith(dest, src any, scoped []Converter) error {
+	if conv, ok := findConverter(scoped, src, dest); ok {
+		return conv.assign(dest, src)
+	}
+
 	// Common cases, without reflect.
 	switch s := src.(type) {
 	case string:
@@ -147,6 +157,20 @@ func convertAssign(dest, src any) error {
 		return nil
 	}
 
+	if d, ok := dest.(*time.Time); ok && d != nil {
+		if t, ok, err := asTime(src); ok {
+			if err != nil {
+				return err
+			}
+			*d = t
+			return nil
+		}
+	}
+
+	if scanner, ok := dest.(scopedScanner); ok && len(scoped) > 0 {
+		return scanner.scanWith(src, scoped)
+	}
+
 	if scanner, ok := dest.(sql.Scanner); ok {
 		return scanner.Scan(src)
 	}
@@ -191,7 +215,7 @@ func convertAssign(dest, src any) error {
 			return nil
 		}
 		dv.Set(reflect.New(dv.Type().Elem()))
-		return convertAssign(dv.Interface(), src)
+		return convertAssignWith(dv.Interface(), src, scoped)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if src == nil {
 			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
@@ -228,6 +252,13 @@ func convertAssign(dest, src any) error {
 		}
 		dv.SetFloat(f64)
 		return nil
+	case reflect.Slice:
+		if s, ok := isPGArrayLiteral(src); ok && isPGArrayType(dv.Type()) {
+			if err := scanPGArray(dv, s); err != nil {
+				return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %v", src, s, dv.Type(), err)
+			}
+			return nil
+		}
 	case reflect.String:
 		if src == nil {
 			return fmt.Errorf("converting NULL to %s is unsupported", dv.Kind())
@@ -0,0 +1,150 @@
+package null
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// Converter converts a source value of a given type into a destination type.
+	// It can be created with NewConverter
+	Converter struct {
+		src reflect.Type
+		dst reflect.Type
+		fn  func(src any) (any, error)
+	}
+
+	// converterKey identifies a converter by its source and destination types
+	converterKey struct {
+		src reflect.Type
+		dst reflect.Type
+	}
+
+	// an internal interface that helps passing scoped converters to nullable variables
+	scopedScanner interface {
+		scanWith(src any, scoped []Converter) error
+	}
+
+	// scanner is an sql.Scanner that uses scoped converters
+	scanner struct {
+		dest   any
+		scoped []Converter
+	}
+)
+
+var (
+	convertersMu    sync.RWMutex
+	converters      = make(map[converterKey]Converter)
+	convertersCount atomic.Int32
+)
+
+// NewConverter creates a Converter from the given function
+func NewConverter[S, D any](fn func(S) (D, error)) Converter {
+	return Converter{
+		src: reflect.TypeOf((*S)(nil)).Elem(),
+		dst: reflect.TypeOf((*D)(nil)).Elem(),
+		fn: func(src any) (any, error) {
+			return fn(src.(S))
+		},
+	}
+}
+
+// RegisterConverter registers a converter that Var.Scan uses to convert
+// values of type S into type D before any built-in conversion. Converters
+// between the same types are never used. Registering a converter for the same types again
+// replaces the previous one. It is safe for concurrent use, but it is meant
+// to be called during initialization.
+func RegisterConverter[S, D any](fn func(S) (D, error)) {
+	c := NewConverter(fn)
+
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	key := converterKey{src: c.src, dst: c.dst}
+	if _, ok := converters[key]; !ok {
+		convertersCount.Add(1)
+	}
+	converters[key] = c
+}
+
+// ScanWith returns an sql.Scanner that scans into dest using the given
+// converters in addition to the registered ones. The given converters take
+// precedence and are only used for this scanner.
+func ScanWith(dest any, convs ...Converter) sql.Scanner {
+	return &scanner{
+		dest:   dest,
+		scoped: convs,
+	}
+}
+
+// Scan implements the sql.Scanner interface
+func (s *scanner) Scan(src any) error {
+	if s.dest == nil {
+		return errNilPtr
+	}
+
+	return convertAssignWith(s.dest, src, s.scoped)
+}
+
+// findConverter looks up a converter for the given source and destination
+// in the scoped converters first and then in the registered ones.
+// Converters between the same types are never used
+func findConverter(scoped []Converter, src, dest any) (Converter, bool) {
+	if src == nil || (len(scoped) == 0 && convertersCount.Load() == 0) {
+		return Converter{}, false
+	}
+
+	dt := reflect.TypeOf(dest)
+	if dt.Kind() != reflect.Pointer {
+		return Converter{}, false
+	}
+
+	key := converterKey{src: reflect.TypeOf(src), dst: dt.Elem()}
+	if key.src == key.dst {
+		return Converter{}, false
+	}
+
+	for _, c := range scoped {
+		if c.src == key.src && c.dst == key.dst {
+			return c, true
+		}
+	}
+
+	if convertersCount.Load() == 0 {
+		return Converter{}, false
+	}
+
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	c, ok := converters[key]
+	return c, ok
+}
+
+// assign converts src and stores the result in dest
+func (c Converter) assign(dest, src any) error {
+	if c.fn == nil {
+		return errors.New("converter is not initialized")
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.IsNil() {
+		return errNilPtr
+	}
+
+	v, err := c.fn(src)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		rv = reflect.Zero(c.dst)
+	}
+
+	dv.Elem().Set(rv)
+	return nil
+}
@@ -0,0 +1,143 @@
+package null
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// converterTestDuration is a custom duration type without sql.Scanner implementation
+type converterTestDuration time.Duration
+
+// converterTestUpper is a custom string type without sql.Scanner implementation
+type converterTestUpper string
+
+// converterTestFlag is a custom string type holding Y or N
+type converterTestFlag string
+
+func TestRegisterConverter(t *testing.T) {
+	var d Var[converterTestDuration]
+
+	// without a converter the reflection fallback is used
+	err := d.Scan("1s")
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("1s") to a int64: invalid syntax`)
+
+	// registering concurrently is safe
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RegisterConverter(func(s string) (converterTestDuration, error) {
+				td, err := time.ParseDuration(s)
+				return converterTestDuration(td), err
+			})
+		}()
+	}
+	wg.Wait()
+
+	err = d.Scan("1s")
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, d, true, true, converterTestDuration(time.Second)); err != nil {
+		t.Errorf("[registered] %v", err)
+	}
+
+	err = d.Scan("foo")
+	assertEqualTerminateTest(t, err.Error(), `time: invalid duration "foo"`)
+
+	// other source types still use the fallbacks
+	err = d.Scan(int64(5))
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, d, true, true, converterTestDuration(5)); err != nil {
+		t.Errorf("[fallback] %v", err)
+	}
+
+	err = d.Scan(nil)
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, d, true, false, converterTestDuration(0)); err != nil {
+		t.Errorf("[nil] %v", err)
+	}
+
+	// converters are used for destinations that have built-in conversions too
+	var b Var[bool]
+	err = b.Scan(converterTestFlag("Y"))
+	assertEqualTerminateTest(t, err.Error(), `sql/driver: couldn't convert Y (null.converterTestFlag) into type bool`)
+
+	RegisterConverter(func(s converterTestFlag) (bool, error) {
+		return s == "Y", nil
+	})
+
+	err = b.Scan(converterTestFlag("Y"))
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, b, true, true, true); err != nil {
+		t.Errorf("[bool] %v", err)
+	}
+
+	var s string
+	err = ScanWith(&s, NewConverter(func(f converterTestFlag) (string, error) {
+		return "flag " + string(f), nil
+	})).Scan(converterTestFlag("N"))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, s, "flag N")
+}
+
+func TestScanWith(t *testing.T) {
+	upper := NewConverter(func(s string) (converterTestUpper, error) {
+		return converterTestUpper(strings.ToUpper(s)), nil
+	})
+	failing := NewConverter(func(s string) (converterTestUpper, error) {
+		return "", errors.New("failing converter")
+	})
+
+	var v Var[converterTestUpper]
+	err := ScanWith(&v, upper).Scan("foo")
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, v, true, true, converterTestUpper("FOO")); err != nil {
+		t.Errorf("[scoped] %v", err)
+	}
+
+	err = ScanWith(&v, upper).Scan(nil)
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, v, true, false, converterTestUpper("")); err != nil {
+		t.Errorf("[scoped nil] %v", err)
+	}
+
+	// the scoped converter is not used outside of ScanWith
+	err = v.Scan("foo")
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, v, true, true, converterTestUpper("foo")); err != nil {
+		t.Errorf("[unscoped] %v", err)
+	}
+
+	// the first matching scoped converter wins
+	var u converterTestUpper
+	err = ScanWith(&u, failing, upper).Scan("foo")
+	assertEqualTerminateTest(t, err.Error(), "failing converter")
+
+	var p *converterTestUpper
+	err = ScanWith(&p, upper).Scan("foo")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, *p, converterTestUpper("FOO"))
+
+	// scoped converters take precedence over the built-in conversions
+	yesNo := NewConverter(func(s string) (bool, error) {
+		return s == "Y", nil
+	})
+
+	var b Var[bool]
+	err = ScanWith(&b, yesNo).Scan("Y")
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, b, true, true, true); err != nil {
+		t.Errorf("[scoped bool] %v", err)
+	}
+
+	var pb bool
+	err = ScanWith(&pb, yesNo).Scan("Y")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, pb, true)
+
+	err = ScanWith(nil, upper).Scan("foo")
+	assertEqualTerminateTest(t, err, errNilPtr)
+}
@@ -0,0 +1,349 @@
+package null
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// CopyEncoder writes structs as rows in the text format of the
+	// PostgreSQL COPY ... FROM STDIN command
+	CopyEncoder[T any] struct {
+		w       io.Writer
+		tag     string
+		columns []string
+		buf     []byte
+	}
+
+	// CopyDecoder reads structs from rows in the text format of the
+	// PostgreSQL COPY ... TO STDOUT command
+	CopyDecoder[T any] struct {
+		r       *bufio.Reader
+		tag     string
+		columns []string
+		line    int
+	}
+)
+
+const (
+	copyNull      = `\N`
+	copyEnd       = `\.`
+	copyTimestamp = "2006-01-02 15:04:05.999999999Z07:00"
+)
+
+// NewCopyEncoder creates an encoder that writes rows of T to w, which must be a struct.
+// Columns are the fields with the db tag, which can be changed with the UseTag option.
+// Each call of Encode writes a single row, so rows are not buffered.
+func NewCopyEncoder[T any](w io.Writer, opts ...option) (*CopyEncoder[T], error) {
+	if w == nil {
+		return nil, errors.New("writer cannot be nil")
+	}
+
+	fOpts, err := newOpts(defaultDBOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := copyColumns[T](fOpts.tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CopyEncoder[T]{
+		w:       w,
+		tag:     fOpts.tag,
+		columns: columns,
+	}, nil
+}
+
+// Columns returns the columns in the order they are written
+func (e *CopyEncoder[T]) Columns() []string {
+	return append([]string{}, e.columns...)
+}
+
+// Encode writes row to the underlying writer. Unset and NULL values are written as \N.
+// Values are encoded by their driver.Value, so Var decides about NULL the same way as in Value
+func (e *CopyEncoder[T]) Encode(row T) error {
+	e.buf = e.buf[:0]
+
+	for i, f := range flatFields(e.tag, reflect.ValueOf(row)) {
+		if i > 0 {
+			e.buf = append(e.buf, '\t')
+		}
+
+		v, err := copyValue(f.value)
+		if err != nil {
+			return fmt.Errorf("column %q: %w", f.name, err)
+		}
+
+		if v == nil {
+			e.buf = append(e.buf, copyNull...)
+			continue
+		}
+		e.buf = appendCopyText(e.buf, v, isCopyBytea(f.value))
+	}
+	e.buf = append(e.buf, '\n')
+
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// NewCopyDecoder creates a decoder that reads rows of T from r, which must be a struct.
+// The columns of the rows must be in the same order as returned by Columns.
+func NewCopyDecoder[T any](r io.Reader, opts ...option) (*CopyDecoder[T], error) {
+	if r == nil {
+		return nil, errors.New("reader cannot be nil")
+	}
+
+	fOpts, err := newOpts(defaultDBOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := copyColumns[T](fOpts.tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CopyDecoder[T]{
+		r:       bufio.NewReader(r),
+		tag:     fOpts.tag,
+		columns: columns,
+	}, nil
+}
+
+// Columns returns the columns in the order they are read
+func (d *CopyDecoder[T]) Columns() []string {
+	return append([]string{}, d.columns...)
+}
+
+// Decode reads the next row into dest. It returns io.EOF when there are no more rows.
+// \N sets nullable fields to NULL and values are converted with the same rules as Scan.
+func (d *CopyDecoder[T]) Decode(dest *T) error {
+	if dest == nil {
+		return errors.New("destination cannot be nil")
+	}
+
+	line, err := d.r.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return err
+	}
+	d.line++
+
+	line = strings.TrimSuffix(line, "\n")
+	if line == copyEnd {
+		return io.EOF
+	}
+
+	cells := strings.Split(line, "\t")
+	if len(cells) != len(d.columns) {
+		return fmt.Errorf("line %d: expected %d columns, got %d", d.line, len(d.columns), len(cells))
+	}
+
+	var row T
+	for i, f := range flatFields(d.tag, reflect.ValueOf(&row).Elem()) {
+		if err := scanCopyValue(f.value, cells[i]); err != nil {
+			return fmt.Errorf("line %d, column %q: %w", d.line, f.name, err)
+		}
+	}
+
+	*dest = row
+	return nil
+}
+
+// copyColumns returns the columns of T, which must be a struct
+func copyColumns[T any](tag string) ([]string, error) {
+	var def T
+	rv := reflect.ValueOf(def)
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. T must be a struct", def)
+	}
+
+	columns := []string{}
+	for _, f := range flatFields(tag, rv) {
+		columns = append(columns, f.name)
+	}
+
+	return columns, nil
+}
+
+// copyValue returns the driver value of the given field, or nil for NULL
+func copyValue(fv reflect.Value) (any, error) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+
+	if dv, ok := fv.Interface().(driver.Valuer); ok {
+		return dv.Value()
+	}
+
+	if isPGArrayType(fv.Type()) {
+		return encodePGArray(fv), nil
+	}
+
+	return fv.Interface(), nil
+}
+
+// appendCopyText appends the escaped text representation of v to buf.
+// If bytea is true []byte values are written in the hex format of bytea,
+// otherwise they are written as text, e.g. JSON documents
+func appendCopyText(buf []byte, v any, bytea bool) []byte {
+	switch val := v.(type) {
+	case []byte:
+		if !bytea {
+			return appendCopyEscaped(buf, string(val))
+		}
+
+		// bytea in hex format, the backslash itself has to be escaped
+		buf = append(buf, `\\x`...)
+		return hex.AppendEncode(buf, val)
+	case bool:
+		if val {
+			return append(buf, 't')
+		}
+		return append(buf, 'f')
+	case time.Time:
+		return val.AppendFormat(buf, copyTimestamp)
+	}
+
+	return appendCopyEscaped(buf, asString(v))
+}
+
+// appendCopyEscaped appends s to buf with the backslash escapes of the COPY text format
+func appendCopyEscaped(buf []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			buf = append(buf, `\\`...)
+		case '\b':
+			buf = append(buf, `\b`...)
+		case '\f':
+			buf = append(buf, `\f`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		case '\v':
+			buf = append(buf, `\v`...)
+		default:
+			buf = append(buf, c)
+		}
+	}
+
+	return buf
+}
+
+// scanCopyValue unescapes the given cell and stores it in the field
+func scanCopyValue(fv reflect.Value, cell string) error {
+	dest := fv.Addr().Interface()
+	if cell == copyNull {
+		return convertAssign(dest, nil)
+	}
+
+	s, err := unescapeCopyText(cell)
+	if err != nil {
+		return err
+	}
+
+	// bytea values are in hex format
+	if isCopyBytea(fv) && strings.HasPrefix(s, `\x`) {
+		b, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return err
+		}
+		return convertAssign(dest, b)
+	}
+
+	return convertAssign(dest, s)
+}
+
+// isCopyBytea returns if the given field holds a byte slice,
+// which is stored in a bytea column
+func isCopyBytea(fv reflect.Value) bool {
+	valueType := fv.Type()
+	if nv, ok := fv.Interface().(nullVar); ok {
+		valueType = nv.valueType()
+	}
+
+	return valueType.Kind() == reflect.Slice && valueType.Elem().Kind() == reflect.Uint8
+}
+
+// unescapeCopyText resolves the backslash escapes of the COPY text format
+func unescapeCopyText(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i == len(s) {
+			return "", errors.New("unterminated escape sequence")
+		}
+
+		switch c = s[i]; c {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// octal value of up to 3 digits
+			j := i + 1
+			for j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7' {
+				j++
+			}
+			n, _ := strconv.ParseUint(s[i:j], 8, 16)
+			b.WriteByte(byte(n))
+			i = j - 1
+		case 'x':
+			// hexadecimal value of up to 2 digits
+			j := i + 1
+			for j < len(s) && j < i+3 && isHexDigit(s[j]) {
+				j++
+			}
+			if j == i+1 {
+				b.WriteByte(c)
+				continue
+			}
+			n, _ := strconv.ParseUint(s[i+1:j], 16, 8)
+			b.WriteByte(byte(n))
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// isHexDigit returns if c is a hexadecimal digit
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
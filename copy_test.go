@@ -0,0 +1,185 @@
+package null
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type copyTestRow struct {
+	ID      int64          `db:"id"`
+	Name    Var[string]    `db:"name"`
+	Age     Var[int]       `db:"age"`
+	Active  Var[bool]      `db:"active"`
+	Data    Var[[]byte]    `db:"data"`
+	Tags    []string       `db:"tags"`
+	Created Var[time.Time] `db:"created"`
+	Score   *float64       `db:"score"`
+	Other   string         `json:"other"`
+}
+
+func TestCopyEncoder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := NewCopyEncoder[copyTestRow](buf)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", enc.Columns()), "[id name age active data tags created score]")
+
+	score := 1.5
+	err = enc.Encode(copyTestRow{
+		ID:      1,
+		Name:    From("tab\there\nnew line \\ backslash"),
+		Age:     From(42),
+		Active:  From(true),
+		Data:    From([]byte{0xde, 0xad}),
+		Tags:    []string{"a", "b c"},
+		Created: From(time.Date(2023, 1, 2, 3, 4, 5, 600, time.UTC)),
+		Score:   &score,
+	})
+	assertEqualTerminateTest(t, err, nil)
+
+	err = enc.Encode(copyTestRow{
+		ID:   2,
+		Name: Null[string](),
+	})
+	assertEqualTerminateTest(t, err, nil)
+
+	assertEqualTerminateTest(t, buf.String(), "1\ttab\\there\\nnew line \\\\ backslash\t42\tt\t\\\\xdead\t{a,\"b c\"}\t2023-01-02 03:04:05.0000006Z\t1.5\n"+
+		"2\t\\N\t\\N\t\\N\t\\N\t{}\t\\N\t\\N\n")
+
+	_, err = NewCopyEncoder[int](buf)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. T must be a struct")
+
+	_, err = NewCopyEncoder[copyTestRow](nil)
+	assertEqualTerminateTest(t, err.Error(), "writer cannot be nil")
+
+	enc2, err := NewCopyEncoder[copyTestRow](&bytes.Buffer{}, UseTag("json"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", enc2.Columns()), "[other]")
+
+	_, err = NewCopyEncoder[copyTestRow](&bytes.Buffer{}, UseNullOp(PatchOpRemove))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+}
+
+func TestCopyDecoder(t *testing.T) {
+	input := "1\ttab\\there\\nnew line \\\\ backslash\t42\tt\t\\\\xdead\t{a,\"b c\"}\t2023-01-02 03:04:05.0000006Z\t1.5\n" +
+		"2\t\\N\t\\N\t\\N\t\\N\t{}\t\\N\t\\N\n" +
+		"3\t\\101\\x42\\C\t7\tf\t\t{}\t2023-01-02\t\\N\n" +
+		"\\.\n"
+
+	dec, err := NewCopyDecoder[copyTestRow](strings.NewReader(input))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", dec.Columns()), "[id name age active data tags created score]")
+
+	var row copyTestRow
+	assertEqualTerminateTest(t, dec.Decode(&row), nil)
+	assertEqualTerminateTest(t, row.ID, 1)
+	assertEqualTerminateTest(t, row.Name, From("tab\there\nnew line \\ backslash"))
+	assertEqualTerminateTest(t, row.Age, From(42))
+	assertEqualTerminateTest(t, row.Active, From(true))
+	assertEqualTerminateTest(t, string(row.Data.Val()), "\xde\xad")
+	assertEqualTerminateTest(t, fmt.Sprintf("%q", row.Tags), `["a" "b c"]`)
+	assertEqualTerminateTest(t, row.Created.Val().Equal(time.Date(2023, 1, 2, 3, 4, 5, 600, time.UTC)), true)
+	assertEqualTerminateTest(t, *row.Score, 1.5)
+
+	assertEqualTerminateTest(t, dec.Decode(&row), nil)
+	assertEqualTerminateTest(t, row.ID, 2)
+	assertEqualTerminateTest(t, row.Name, Null[string]())
+	assertEqualTerminateTest(t, row.Age, Null[int]())
+	assertEqualTerminateTest(t, row.Data.State(), StateNull)
+	assertEqualTerminateTest(t, len(row.Tags), 0)
+	assertEqualTerminateTest(t, row.Created.State(), StateNull)
+	assertEqualTerminateTest(t, row.Score, nil)
+
+	assertEqualTerminateTest(t, dec.Decode(&row), nil)
+	assertEqualTerminateTest(t, row.Name, From("ABC"))
+	assertEqualTerminateTest(t, row.Active, From(false))
+	assertEqualTerminateTest(t, string(row.Data.Val()), "")
+	assertEqualTerminateTest(t, len(row.Tags), 0)
+	assertEqualTerminateTest(t, row.Created.Val().Equal(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)), true)
+
+	assertEqualTerminateTest(t, errors.Is(dec.Decode(&row), io.EOF), true)
+
+	// the end marker is optional
+	dec, err = NewCopyDecoder[copyTestRow](strings.NewReader("4\tx\t1\tt\t\t{}\t\\N\t\\N"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, dec.Decode(&row), nil)
+	assertEqualTerminateTest(t, row.ID, 4)
+	assertEqualTerminateTest(t, errors.Is(dec.Decode(&row), io.EOF), true)
+
+	dec, _ = NewCopyDecoder[copyTestRow](strings.NewReader("1\t2\n"))
+	assertEqualTerminateTest(t, dec.Decode(&row).Error(), "line 1: expected 8 columns, got 2")
+
+	dec, _ = NewCopyDecoder[copyTestRow](strings.NewReader("x\t\t\t\t\t\t\t\n"))
+	assertEqualTerminateTest(t, dec.Decode(&row).Error(), `line 1, column "id": converting driver.Value type string ("x") to a int64: invalid syntax`)
+
+	dec, _ = NewCopyDecoder[copyTestRow](strings.NewReader("\\N\t\t\t\t\t\t\t\n"))
+	assertEqualTerminateTest(t, dec.Decode(&row).Error(), `line 1, column "id": converting NULL to int64 is unsupported`)
+
+	dec, _ = NewCopyDecoder[copyTestRow](strings.NewReader("1\tfoo\\\t\t\t\t\t\t\n"))
+	assertEqualTerminateTest(t, dec.Decode(&row).Error(), `line 1, column "name": unterminated escape sequence`)
+
+	assertEqualTerminateTest(t, dec.Decode(nil).Error(), "destination cannot be nil")
+
+	_, err = NewCopyDecoder[copyTestRow](nil)
+	assertEqualTerminateTest(t, err.Error(), "reader cannot be nil")
+
+	_, err = NewCopyDecoder[copyTestRow](strings.NewReader(""), UseTag("json"), UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+}
+
+func TestCopyRoundTrip(t *testing.T) {
+	// unset values can't be represented, so they are decoded as NULL
+	rows := []copyTestRow{
+		{ID: 1, Name: From("\\N"), Tags: []string{}},
+		{ID: 2, Name: From("\\."), Data: From([]byte("\\x")), Tags: []string{"x"}},
+		{ID: 3, Name: From("a\r\b\f\vb"), Tags: []string{}},
+	}
+
+	buf := &bytes.Buffer{}
+	enc, _ := NewCopyEncoder[copyTestRow](buf)
+	for _, row := range rows {
+		assertEqualTerminateTest(t, enc.Encode(row), nil)
+	}
+
+	dec, _ := NewCopyDecoder[copyTestRow](buf)
+	for _, want := range rows {
+		var got copyTestRow
+		assertEqualTerminateTest(t, dec.Decode(&got), nil)
+		assertEqualTerminateTest(t, got.ID, want.ID)
+		assertEqualTerminateTest(t, got.Name, want.Name)
+		assertEqualTerminateTest(t, string(got.Data.Val()), string(want.Data.Val()))
+		assertEqualTerminateTest(t, fmt.Sprintf("%q", got.Tags), fmt.Sprintf("%q", want.Tags))
+		assertEqualTerminateTest(t, got.Age, Null[int]())
+	}
+	assertEqualTerminateTest(t, errors.Is(dec.Decode(&copyTestRow{}), io.EOF), true)
+}
+
+func TestCopyJSON(t *testing.T) {
+	type row struct {
+		ID  int64                      `db:"id"`
+		Doc Var[JSON[jsonTestPayload]] `db:"doc"`
+		Raw Var[[]byte]                `db:"raw"`
+	}
+
+	buf := &bytes.Buffer{}
+	enc, _ := NewCopyEncoder[row](buf)
+	want := row{
+		ID:  1,
+		Doc: From(NewJSON(jsonTestPayload{Name: "a\tb\\c", Tags: []int{1}})),
+		Raw: From([]byte("{}")),
+	}
+	assertEqualTerminateTest(t, enc.Encode(want), nil)
+
+	// JSON documents are written as text, only byte slices are in the bytea format
+	assertEqualTerminateTest(t, buf.String(), "1\t{\"name\":\"a\\\\tb\\\\\\\\c\",\"tags\":[1]}\t\\\\x7b7d\n")
+
+	dec, _ := NewCopyDecoder[row](buf)
+	var got row
+	assertEqualTerminateTest(t, dec.Decode(&got), nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", got.Doc.Val().V), "{Name:a\tb\\c Tags:[1]}")
+	assertEqualTerminateTest(t, string(got.Raw.Val()), "{}")
+}
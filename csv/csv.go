@@ -0,0 +1,290 @@
+// Package csv encodes and decodes slices of structs with nullable fields as CSV.
+//
+// Columns are matched to struct fields by the csv tag, the same way as
+// null.FilterStruct matches the json tag. Unset and NULL values are written
+// as the NULL token, while cells holding the NULL token are decoded as NULL
+// into nullable fields and pointers. Other fields decode the token as a value.
+// Nullable fields without a column are left unset.
+package csv
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/mauserzjeh/null"
+)
+
+type (
+	options struct {
+		tag   string
+		null  string
+		comma rune
+	}
+
+	option func(o *options)
+)
+
+var (
+	defaultOptions = options{
+		tag:   "csv",
+		null:  "",
+		comma: ',',
+	}
+)
+
+// UseTag sets the struct tag that is used to match columns to fields.
+// The default is "csv"
+func UseTag(tag string) option {
+	if tag == "" {
+		return func(o *options) {}
+	}
+
+	return func(o *options) {
+		o.tag = tag
+	}
+}
+
+// UseNull sets the token that represents NULL values, e.g. `\N` for
+// MySQL and PostgreSQL exports. The default is an empty string
+func UseNull(token string) option {
+	return func(o *options) {
+		o.null = token
+	}
+}
+
+// UseComma sets the field delimiter. The default is ','
+func UseComma(comma rune) option {
+	return func(o *options) {
+		o.comma = comma
+	}
+}
+
+// Marshal encodes the given slice of structs or pointers to structs as CSV.
+// The first record is the header made of the tag names of the fields.
+// Values are encoded by their MarshalText method or by their driver.Value
+// if they have one, otherwise basic kinds are formatted with the strconv package
+func Marshal(v any, opts ...option) ([]byte, error) {
+	o := newOptions(opts)
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("invalid type %T. input must be a slice of structs", v)
+	}
+
+	elemType, err := structType(rv.Type().Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	w := stdcsv.NewWriter(buf)
+	w.Comma = o.comma
+
+	fields := null.StructFields(reflect.New(elemType), null.UseTag(o.tag))
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.Name
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		ev := rv.Index(i)
+		if ev.Kind() == reflect.Pointer && ev.IsNil() {
+			return nil, fmt.Errorf("row %d: nil pointer", i)
+		}
+
+		fields := null.StructFields(ev, null.UseTag(o.tag))
+		record := make([]string, len(fields))
+		for j, f := range fields {
+			cell, err := encodeCell(f.Value, o.null)
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", i, f.Name, err)
+			}
+			record[j] = cell
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the given CSV into v, which must be a pointer to a slice
+// of structs or pointers to structs. The slice is replaced by the decoded rows
+// and the first record must be the header. Columns are matched to fields by
+// their tag names and columns without a matching field are ignored.
+// Cells are converted with the same rules as Var.Scan
+func Unmarshal(data []byte, v any, opts ...option) error {
+	o := newOptions(opts)
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("invalid type %T. destination must be a non-nil pointer to a slice of structs", v)
+	}
+
+	sliceValue := rv.Elem()
+	elemType := sliceValue.Type().Elem()
+	structTyp, err := structType(elemType)
+	if err != nil {
+		return err
+	}
+	sliceValue.SetLen(0)
+
+	r := stdcsv.NewReader(bytes.NewReader(data))
+	r.Comma = o.comma
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		row := reflect.New(structTyp)
+		fields := make(map[string]reflect.Value)
+		for _, f := range null.StructFields(row, null.UseTag(o.tag)) {
+			fields[f.Name] = f.Value
+		}
+
+		for i, cell := range record {
+			if i >= len(header) {
+				break
+			}
+
+			field, ok := fields[header[i]]
+			if !ok {
+				continue
+			}
+
+			if err := decodeCell(field, cell, o.null); err != nil {
+				return fmt.Errorf("line %d, column %q: %w", line, header[i], err)
+			}
+		}
+
+		if elemType.Kind() == reflect.Pointer {
+			sliceValue.Set(reflect.Append(sliceValue, row))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, row.Elem()))
+		}
+	}
+}
+
+// newOptions applies the given options on the default ones
+func newOptions(opts []option) options {
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// structType returns the struct type of a slice element, which can be a
+// struct or a pointer to a struct
+func structType(t reflect.Type) (reflect.Type, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid element type %s. elements must be structs", t)
+	}
+
+	return t, nil
+}
+
+// encodeCell encodes the given field value as a CSV cell
+func encodeCell(fv reflect.Value, nullToken string) (string, error) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nullToken, nil
+		}
+		fv = fv.Elem()
+	}
+
+	value := fv.Interface()
+	if sv, ok := value.(interface{ State() null.State }); ok && sv.State() != null.StateValue {
+		return nullToken, nil
+	}
+
+	if tm, ok := value.(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		if err == nil {
+			return string(b), nil
+		}
+
+		// fall back to the driver value, e.g. for PostgreSQL arrays
+		if _, ok := value.(driver.Valuer); !ok {
+			return "", err
+		}
+	}
+
+	if dv, ok := value.(driver.Valuer); ok {
+		v, err := dv.Value()
+		if err != nil {
+			return "", err
+		}
+		if v == nil {
+			return nullToken, nil
+		}
+		value = v
+	}
+
+	if tm, ok := value.(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	var s string
+	if err := null.ScanWith(&s).Scan(value); err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
+
+// decodeCell decodes the given CSV cell into the field.
+// The NULL token is only decoded as NULL into nullable fields and pointers
+func decodeCell(fv reflect.Value, cell, nullToken string) error {
+	dest := fv.Addr().Interface()
+
+	_, isNullVar := fv.Interface().(interface{ State() null.State })
+	if cell == nullToken && (isNullVar || fv.Kind() == reflect.Pointer) {
+		return null.ScanWith(dest).Scan(nil)
+	}
+
+	if _, ok := dest.(sql.Scanner); !ok {
+		if tu, ok := dest.(encoding.TextUnmarshaler); ok {
+			return tu.UnmarshalText([]byte(cell))
+		}
+	}
+
+	return null.ScanWith(dest).Scan(cell)
+}
@@ -0,0 +1,169 @@
+package csv
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mauserzjeh/null"
+)
+
+// assertEqualTerminateTest makes the test fail if the two values are not equal
+func assertEqualTerminateTest[T comparable](t testing.TB, got, want T) {
+	t.Helper()
+
+	if got != want {
+		t.Errorf("got: %v != want: %v", got, want)
+	}
+}
+
+type Base struct {
+	null.Filterable
+
+	ID int64 `csv:"id"`
+}
+
+type record struct {
+	null.Filterable
+	Base
+
+	Name    null.Var[string]    `csv:"name"`
+	Age     null.Var[int]       `csv:"age"`
+	Score   *float64            `csv:"score"`
+	Created null.Var[time.Time] `csv:"created"`
+	Ignored string              `csv:"-"`
+	NoTag   string
+}
+
+func TestMarshal(t *testing.T) {
+	score := 1.5
+	rows := []record{
+		{
+			Base:    Base{ID: 1},
+			Name:    null.From("foo"),
+			Age:     null.From(42),
+			Score:   &score,
+			Created: null.From(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Ignored: "ignored",
+		},
+		{
+			Base: Base{ID: 2},
+			Name: null.From("bar, baz"),
+			Age:  null.Null[int](),
+		},
+	}
+
+	got, err := Marshal(rows)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, string(got), "id,name,age,score,created\n"+
+		"1,foo,42,1.5,2023-01-02T03:04:05Z\n"+
+		"2,\"bar, baz\",,,\n")
+
+	got, err = Marshal(&rows, UseNull(`\N`), UseComma(';'))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, string(got), "id;name;age;score;created\n"+
+		"1;foo;42;1.5;2023-01-02T03:04:05Z\n"+
+		"2;bar, baz;\\N;\\N;\\N\n")
+
+	got, err = Marshal([]*record{})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, string(got), "id,name,age,score,created\n")
+
+	_, err = Marshal([]*record{nil})
+	assertEqualTerminateTest(t, err.Error(), "row 0: nil pointer")
+
+	_, err = Marshal(record{})
+	assertEqualTerminateTest(t, err.Error(), "invalid type csv.record. input must be a slice of structs")
+
+	_, err = Marshal([]int{1})
+	assertEqualTerminateTest(t, err.Error(), "invalid element type int. elements must be structs")
+}
+
+func TestUnmarshal(t *testing.T) {
+	data := "name,id,unknown,age,score,created\n" +
+		"foo,1,x,42,1.5,2023-01-02T03:04:05Z\n" +
+		`bar,2,y,\N,\N,\N` + "\n" +
+		",3,z,7,2,\\N\n"
+
+	var rows []record
+	err := Unmarshal([]byte(data), &rows, UseNull(`\N`))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(rows), 3)
+
+	assertEqualTerminateTest(t, rows[0].ID, 1)
+	assertEqualTerminateTest(t, rows[0].Name, null.From("foo"))
+	assertEqualTerminateTest(t, rows[0].Age, null.From(42))
+	assertEqualTerminateTest(t, *rows[0].Score, 1.5)
+	assertEqualTerminateTest(t, rows[0].Created.Val().Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), true)
+
+	assertEqualTerminateTest(t, rows[1].ID, 2)
+	assertEqualTerminateTest(t, rows[1].Name, null.From("bar"))
+	assertEqualTerminateTest(t, rows[1].Age, null.Null[int]())
+	assertEqualTerminateTest(t, rows[1].Score, nil)
+	assertEqualTerminateTest(t, rows[1].Created.State(), null.StateNull)
+
+	// empty cells are values unless the NULL token is empty
+	assertEqualTerminateTest(t, rows[2].Name, null.From(""))
+
+	var ptrRows []*record
+	err = Unmarshal([]byte("id,age\n1,\n2,5\n"), &ptrRows)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(ptrRows), 2)
+	assertEqualTerminateTest(t, ptrRows[0].Age, null.Null[int]())
+	assertEqualTerminateTest(t, ptrRows[1].Age, null.From(5))
+
+	// nullable fields without a column are unset
+	assertEqualTerminateTest(t, ptrRows[0].Name, null.Unset[string]())
+
+	// the destination is replaced
+	err = Unmarshal([]byte("id;name\n1;foo\n"), &ptrRows, UseComma(';'))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(ptrRows), 1)
+	assertEqualTerminateTest(t, ptrRows[0].Name, null.From("foo"))
+
+	// the NULL token is a value in plain fields
+	type plain struct {
+		Code string `csv:"code"`
+		Note string `csv:"note"`
+	}
+
+	var plainRows []plain
+	err = Unmarshal([]byte("code,note\na,\n,b\n"), &plainRows)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(plainRows), 2)
+	assertEqualTerminateTest(t, plainRows[0], plain{Code: "a"})
+	assertEqualTerminateTest(t, plainRows[1], plain{Note: "b"})
+
+	err = Unmarshal([]byte("code,note\n\\N,b\n"), &plainRows, UseNull(`\N`))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, plainRows[0], plain{Code: `\N`, Note: "b"})
+
+	err = Unmarshal([]byte("id,age\n1,foo\n"), &rows)
+	assertEqualTerminateTest(t, err.Error(), `line 2, column "age": converting driver.Value type string ("foo") to a int: invalid syntax`)
+
+	err = Unmarshal([]byte("id,age\n,1\n"), &rows)
+	assertEqualTerminateTest(t, err.Error(), `line 2, column "id": converting driver.Value type string ("") to a int64: invalid syntax`)
+
+	err = Unmarshal([]byte(""), rows)
+	assertEqualTerminateTest(t, err.Error(), "invalid type []csv.record. destination must be a non-nil pointer to a slice of structs")
+
+	err = Unmarshal([]byte(""), &rows)
+	assertEqualTerminateTest(t, err, nil)
+}
+
+func TestCustomTag(t *testing.T) {
+	type row struct {
+		A null.Var[string] `db:"a"`
+		B null.Var[string] `csv:"b"`
+	}
+
+	got, err := Marshal([]row{{A: null.From("a"), B: null.From("b")}}, UseTag("db"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, string(got), "a\na\n")
+
+	var rows []row
+	err = Unmarshal([]byte("a,b\nx,y\n"), &rows, UseTag("db"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", rows[0].A.Val()), "x")
+	assertEqualTerminateTest(t, rows[0].B.IsSet(), false)
+}
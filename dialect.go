@@ -0,0 +1,263 @@
+package null
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// PlaceholderStyle defines how query parameters are written
+	PlaceholderStyle uint8
+
+	// Dialect defines how SQL statements are written for a given database
+	Dialect struct {
+		Placeholder PlaceholderStyle // the style of the query parameters
+		QuoteStart  string           // the opening character of quoted identifiers, empty to disable quoting
+		QuoteEnd    string           // the closing character of quoted identifiers
+
+		upsert    upsertStyle // the syntax used for upserts
+		noDefault bool        // if the DEFAULT keyword can't be used in VALUES
+	}
+
+	// upsertStyle defines the syntax of upsert statements
+	upsertStyle uint8
+
+	// sqlColumn is a column and its value selected from a struct
+	sqlColumn struct {
+		name  string
+		value any
+	}
+
+	sqlBuilderOpts struct {
+		filterOpts
+		dialect Dialect // the dialect of the statements
+	}
+
+	// sqlBuilder writes an SQL statement and collects its arguments
+	sqlBuilder struct {
+		dialect Dialect
+		buf     strings.Builder
+		args    []any
+		names   map[string]int
+	}
+)
+
+const (
+	PlaceholderQuestion PlaceholderStyle = iota // ?
+	PlaceholderDollar                           // $1, $2, ...
+	PlaceholderColon                            // :name
+	PlaceholderAt                               // @p1, @p2, ...
+)
+
+const (
+	upsertNone upsertStyle = iota
+	upsertOnConflict
+	upsertOnDuplicateKey
+)
+
+var (
+	// DialectDefault uses ? placeholders and unquoted identifiers
+	DialectDefault = Dialect{Placeholder: PlaceholderQuestion}
+
+	// DialectPostgres is the dialect of PostgreSQL
+	DialectPostgres = Dialect{Placeholder: PlaceholderDollar, QuoteStart: `"`, QuoteEnd: `"`, upsert: upsertOnConflict}
+
+	// DialectMySQL is the dialect of MySQL and MariaDB
+	DialectMySQL = Dialect{Placeholder: PlaceholderQuestion, QuoteStart: "`", QuoteEnd: "`", upsert: upsertOnDuplicateKey}
+
+	// DialectSQLite is the dialect of SQLite
+	DialectSQLite = Dialect{Placeholder: PlaceholderQuestion, QuoteStart: `"`, QuoteEnd: `"`, upsert: upsertOnConflict, noDefault: true}
+
+	// DialectSQLServer is the dialect of Microsoft SQL Server
+	DialectSQLServer = Dialect{Placeholder: PlaceholderAt, QuoteStart: "[", QuoteEnd: "]"}
+
+	// DialectOracle is the dialect of Oracle
+	DialectOracle = Dialect{Placeholder: PlaceholderColon, QuoteStart: `"`, QuoteEnd: `"`}
+)
+
+var (
+	// defaultDBOpts are the options of the helpers working with database rows.
+	// Unlike the other helpers they use the db tag by default
+	defaultDBOpts = filterOpts{tag: "db"}
+
+	// defaultSQLBuilderOpts are the options of the SQL statement builders
+	defaultSQLBuilderOpts = sqlBuilderOpts{
+		filterOpts: defaultDBOpts,
+		dialect:    DialectDefault,
+	}
+)
+
+// UseDialect sets the SQL dialect used by the statement builders
+func UseDialect(d Dialect) optFunc[sqlBuilderOpts] {
+	return func(o *sqlBuilderOpts) {
+		o.dialect = d
+	}
+}
+
+// QuoteIdent quotes the given identifier according to the dialect.
+// Qualified identifiers like schema.table are quoted part by part
+func (d Dialect) QuoteIdent(ident string) string {
+	if d.QuoteStart == "" {
+		return ident
+	}
+
+	parts := strings.Split(ident, ".")
+	for i, p := range parts {
+		parts[i] = d.QuoteStart + strings.ReplaceAll(p, d.QuoteEnd, d.QuoteEnd+d.QuoteEnd) + d.QuoteEnd
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// sqlColumns returns the columns of the given struct.
+// Unset nullable fields are left out, while set ones are used as is so that
+// Var.Value handles NULL. The fields of embedded Filterable structs without
+// a tag name are promoted, unless a column with the same name already exists.
+func sqlColumns(tag string, val reflect.Value) []sqlColumn {
+	columns := []sqlColumn{}
+	index := make(map[string]int)
+
+	put := func(c sqlColumn, override bool) {
+		if i, ok := index[c.name]; ok {
+			if override {
+				columns[i] = c
+			}
+			return
+		}
+
+		index[c.name] = len(columns)
+		columns = append(columns, c)
+	}
+
+	for _, f := range structFields(tag, val) {
+		fieldValue := f.value.Interface()
+
+		if nv, ok := fieldValue.(nullVar); ok {
+			if nv.isSet() {
+				put(sqlColumn{name: f.name, value: fieldValue}, true)
+			}
+			continue
+		}
+
+		if f.embedded && f.name == "" {
+			if _, ok := fieldValue.(Filterable); ok {
+				for _, c := range sqlColumns(tag, f.value) {
+					put(c, false)
+				}
+			}
+			continue
+		}
+
+		put(sqlColumn{name: f.name, value: fieldValue}, true)
+	}
+
+	return columns
+}
+
+// newSQLBuilder creates a new sqlBuilder for the given dialect
+func newSQLBuilder(d Dialect) *sqlBuilder {
+	return &sqlBuilder{
+		dialect: d,
+		args:    []any{},
+		names:   make(map[string]int),
+	}
+}
+
+// write writes the given strings as they are
+func (b *sqlBuilder) write(s ...string) {
+	for _, str := range s {
+		b.buf.WriteString(str)
+	}
+}
+
+// ident writes the given identifier quoted
+func (b *sqlBuilder) ident(s string) {
+	b.buf.WriteString(b.dialect.QuoteIdent(s))
+}
+
+// bind writes a placeholder for the given value and collects it as an argument.
+// The name is only used for named placeholders
+func (b *sqlBuilder) bind(name string, v any) {
+	switch b.dialect.Placeholder {
+	case PlaceholderDollar:
+		b.args = append(b.args, v)
+		b.write("$", strconv.Itoa(len(b.args)))
+	case PlaceholderAt:
+		b.args = append(b.args, v)
+		b.write("@p", strconv.Itoa(len(b.args)))
+	case PlaceholderColon:
+		name = b.uniqueName(name)
+		b.args = append(b.args, sql.Named(name, v))
+		b.write(":", name)
+	default:
+		b.args = append(b.args, v)
+		b.write("?")
+	}
+}
+
+// uniqueName turns name into a valid parameter name that was not used yet
+func (b *sqlBuilder) uniqueName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "p" + name
+	}
+
+	b.names[name]++
+	if n := b.names[name]; n > 1 {
+		return b.uniqueName(name + "_" + strconv.Itoa(n))
+	}
+
+	return name
+}
+
+// clause writes a user supplied clause. The ? placeholders outside of quoted
+// strings are rewritten to the placeholder style of the dialect. With named
+// placeholders the clause and its arguments are used as they are.
+func (b *sqlBuilder) clause(clause string, args []any) error {
+	if b.dialect.Placeholder == PlaceholderColon {
+		b.write(clause)
+		b.args = append(b.args, args...)
+		return nil
+	}
+
+	n := 0
+	var quote rune
+	for _, r := range clause {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '?':
+			if n >= len(args) {
+				return fmt.Errorf("not enough arguments for clause %q", clause)
+			}
+			b.bind("", args[n])
+			n++
+			continue
+		}
+		b.buf.WriteRune(r)
+	}
+
+	if n != len(args) {
+		return fmt.Errorf("too many arguments for clause %q", clause)
+	}
+
+	return nil
+}
+
+// String returns the written statement
+func (b *sqlBuilder) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,162 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Diff returns the changes between before and after. In the result only the nullable
+// fields that changed are set. They hold the new value, or NULL if the value was cleared.
+// Unset and NULL count as the same, since neither of them holds a value.
+// The top level struct, Filterable structs and structs with nullable fields are compared
+// recursively, while other fields hold the new value if it changed and their zero value otherwise. Values are compared by their Equal method
+// if they have one, e.g. time.Time, otherwise by reflect.DeepEqual.
+func Diff[T any](before, after T) T {
+	var r T
+	diffValue(reflect.ValueOf(&r).Elem(), reflect.ValueOf(&before).Elem(), reflect.ValueOf(&after).Elem(), true)
+	return r
+}
+
+// DiffMap returns the changes between before and after, which must be structs,
+// as a map keyed by their tags the same way as FilterStruct does.
+// The tag can be changed with the UseTag option. Changed nullable fields hold
+// the new value or nil if it was cleared, while Filterable structs are compared
+// recursively. Values are compared the same way as in Diff.
+func DiffMap[T any](before, after T, opts ...option) (map[string]any, error) {
+	av := reflect.ValueOf(after)
+	if !av.IsValid() {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if av.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. input must be a struct", after)
+	}
+
+	bv := reflect.ValueOf(before)
+	if !bv.IsValid() || bv.Type() != av.Type() {
+		return nil, fmt.Errorf("type mismatch %T != %T", before, after)
+	}
+
+	// set options
+	fOpts, err := newOpts(defaultFilterOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffMap(fOpts.tag, bv, av), nil
+}
+
+// diffValue stores the changes between before and after in dst.
+// top is true for the top level value
+func diffValue(dst, before, after reflect.Value, top bool) {
+	if a, ok := after.Interface().(nullVar); ok {
+		if !nullVarChanged(before, a) {
+			return
+		}
+
+		if a.getVal() == nil {
+			// dst can be an interface field as well
+			v := reflect.New(reflect.TypeOf(a))
+			v.Interface().(nullVarPtr).SetNil()
+			dst.Set(v.Elem())
+			return
+		}
+		dst.Set(after)
+		return
+	}
+
+	if walkStruct(after, top) {
+		for i := 0; i < after.NumField(); i++ {
+			if !dst.Field(i).CanSet() {
+				continue
+			}
+			diffValue(dst.Field(i), before.Field(i), after.Field(i), false)
+		}
+		return
+	}
+
+	if !valuesEqual(before, after) {
+		dst.Set(after)
+	}
+}
+
+// diffMap creates a map from the changes between the before and after structs
+func diffMap(tag string, before, after reflect.Value) map[string]any {
+	changes := make(map[string]any)
+
+	for _, f := range structFields(tag, after) {
+		b := before.FieldByIndex(f.field.Index)
+		a := f.value
+
+		switch aVal := a.Interface().(type) {
+		case nullVar:
+			if !nullVarChanged(b, aVal) {
+				continue
+			}
+			changes[f.name] = aVal.getVal()
+
+		case Filterable:
+			if a.Kind() != reflect.Struct {
+				continue
+			}
+			fc := diffMap(tag, b, a)
+
+			// if embedded then the fields need to be on the same level as others
+			if f.embedded && f.name == "" {
+				for k, v := range fc {
+					if _, ok := changes[k]; !ok {
+						changes[k] = v
+					}
+				}
+				continue
+			}
+
+			if len(fc) > 0 {
+				changes[f.name] = fc
+			}
+
+		default:
+			if f.name == "" || valuesEqual(b, a) {
+				continue
+			}
+			changes[f.name] = nilIfEmpty(a)
+		}
+	}
+
+	return changes
+}
+
+// nullVarChanged returns if after differs from before. In interface fields before
+// might not be a nullable variable, then they only equal if neither holds a value
+func nullVarChanged(before reflect.Value, after nullVar) bool {
+	if b, ok := before.Interface().(nullVar); ok {
+		return !nullVarsEqual(b, after)
+	}
+
+	return nilIfEmpty(before) != nil || after.getVal() != nil
+}
+
+// nullVarsEqual returns if the two nullable variables hold the same value.
+// Unset and NULL variables are equal
+func nullVarsEqual(before, after nullVar) bool {
+	b, a := before.getVal(), after.getVal()
+	if b == nil || a == nil {
+		return b == nil && a == nil
+	}
+
+	return valuesEqual(reflect.ValueOf(b), reflect.ValueOf(a))
+}
+
+// valuesEqual compares the two values by the Equal method of after if it has
+// one with the signature Equal(T) bool, otherwise by reflect.DeepEqual
+func valuesEqual(before, after reflect.Value) bool {
+	if m := after.MethodByName("Equal"); m.IsValid() {
+		mt := m.Type()
+		if mt.NumIn() == 1 && mt.NumOut() == 1 && mt.In(0) == after.Type() && mt.Out(0).Kind() == reflect.Bool {
+			return m.Call([]reflect.Value{before})[0].Bool()
+		}
+	}
+
+	return reflect.DeepEqual(before.Interface(), after.Interface())
+}
@@ -0,0 +1,179 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type DiffTestBase struct {
+	Filterable
+
+	ID      int64          `json:"id"`
+	Updated Var[time.Time] `json:"updated"`
+}
+
+type DiffTestAddress struct {
+	Filterable
+
+	City Var[string] `json:"city"`
+	Zip  Var[string] `json:"zip"`
+}
+
+type DiffTestEntity struct {
+	Filterable
+	DiffTestBase
+
+	Name    Var[string]              `json:"name"`
+	Email   Var[string]              `json:"email"`
+	Age     Var[int]                 `json:"age"`
+	Nums    Var[customDefinedSlice]  `json:"nums"`
+	Tags    []string                 `json:"tags"`
+	Address DiffTestAddress          `json:"address"`
+	Meta    Var[map[string]any]      `json:"meta"`
+	Custom  Var[customDefinedStruct] `json:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	before := DiffTestEntity{
+		DiffTestBase: DiffTestBase{ID: 1, Updated: From(ts)},
+		Name:         From("foo"),
+		Email:        From("foo@example.com"),
+		Age:          Null[int](),
+		Nums:         From(customDefinedSlice{}),
+		Tags:         []string{"a"},
+		Address:      DiffTestAddress{City: From("Budapest"), Zip: From("1000")},
+		Meta:         From(map[string]any{"a": 1}),
+	}
+
+	after := before
+	after.Updated = From(ts.In(time.FixedZone("CET", 3600)))
+	after.Email = Null[string]()
+	after.Age = Unset[int]()
+	after.Nums = From(customDefinedSlice(nil))
+	after.Tags = []string{"a", "b"}
+	after.Address.Zip = From("1011")
+	after.Meta = From(map[string]any{"a": 1})
+
+	d := Diff(before, after)
+	assertEqualTerminateTest(t, d.ID, 0)
+	assertEqualTerminateTest(t, d.Updated.IsSet(), false)
+	assertEqualTerminateTest(t, d.Name, Unset[string]())
+	assertEqualTerminateTest(t, d.Email, Null[string]())
+	assertEqualTerminateTest(t, d.Age, Unset[int]())
+	assertEqualTerminateTest(t, d.Nums.IsSet(), false)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", d.Tags), "[a b]")
+	assertEqualTerminateTest(t, d.Address.City, Unset[string]())
+	assertEqualTerminateTest(t, d.Address.Zip, From("1011"))
+	assertEqualTerminateTest(t, d.Meta.IsSet(), false)
+
+	after.Name = From("bar")
+	after.Age = From(42)
+	after.Custom = From(customDefinedStruct{Slice: []int{1}})
+	d = Diff(before, after)
+	assertEqualTerminateTest(t, d.Name, From("bar"))
+	assertEqualTerminateTest(t, d.Age, From(42))
+	assertEqualTerminateTest(t, d.Custom.Val().Equal(customDefinedStruct{Slice: []int{1}}), true)
+
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", Diff(before, before)), fmt.Sprintf("%+v", DiffTestEntity{}))
+
+	// values other than structs
+	assertEqualTerminateTest(t, Diff(From(1), From(1)), Unset[int]())
+	assertEqualTerminateTest(t, Diff(From(1), From(2)), From(2))
+	assertEqualTerminateTest(t, Diff(From(1), Unset[int]()), Null[int]())
+	assertEqualTerminateTest(t, Diff(1, 2), 2)
+	assertEqualTerminateTest(t, Diff(ts, ts.Add(time.Hour)), ts.Add(time.Hour))
+
+	// structs that don't implement Filterable
+	type Limits struct {
+		Max Var[int]
+		Min Var[int]
+	}
+
+	type Plain struct {
+		Name   Var[string]
+		Level  string
+		Limits Limits
+	}
+
+	p := Diff(
+		Plain{Name: From("a"), Level: "info", Limits: Limits{Max: From(10), Min: From(1)}},
+		Plain{Name: From("b"), Level: "info", Limits: Limits{Max: From(10), Min: From(2)}},
+	)
+	assertEqualTerminateTest(t, p, Plain{Name: From("b"), Limits: Limits{Min: From(2)}})
+
+	// interface fields holding different types
+	type Any struct {
+		X any `json:"x"`
+	}
+
+	assertEqualTerminateTest(t, Diff(Any{}, Any{X: From(1)}), Any{X: From(1)})
+	assertEqualTerminateTest(t, Diff(Any{X: 1}, Any{X: Unset[int]()}), Any{X: Null[int]()})
+	assertEqualTerminateTest(t, Diff(Any{X: From(1)}, Any{X: Null[int]()}), Any{X: Null[int]()})
+	assertEqualTerminateTest(t, Diff(Any{}, Any{X: Null[int]()}), Any{})
+	assertEqualTerminateTest(t, Diff(Any{X: From(1)}, Any{X: 1}), Any{X: 1})
+}
+
+func TestDiffMap(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	before := DiffTestEntity{
+		DiffTestBase: DiffTestBase{ID: 1, Updated: From(ts)},
+		Name:         From("foo"),
+		Email:        From("foo@example.com"),
+		Tags:         []string{"a"},
+		Address:      DiffTestAddress{City: From("Budapest"), Zip: From("1000")},
+	}
+
+	after := before
+	after.ID = 2
+	after.Updated = From(ts.Add(time.Hour))
+	after.Name = From("bar")
+	after.Email = Null[string]()
+	after.Tags = nil
+	after.Address.Zip = From("1011")
+	after.Custom = From(customDefinedStruct{Str: "ignored"})
+
+	m, err := DiffMap(before, after)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", m), "map[address:map[zip:1011] email:<nil> id:2 name:bar tags:<nil> updated:2023-01-02 04:04:05 +0000 UTC]")
+
+	m, err = DiffMap(before, before)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(m), 0)
+
+	m, err = DiffMap(before, after, UseTag("db"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(m), 0)
+
+	// interface fields holding different types
+	type Any struct {
+		X any `json:"x"`
+	}
+
+	m, err = DiffMap(Any{}, Any{X: From(1)})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", m), "map[x:1]")
+
+	m, err = DiffMap(Any{X: "a"}, Any{X: Null[int]()})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", m), "map[x:<nil>]")
+
+	m, err = DiffMap(Any{}, Any{X: Unset[int]()})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(m), 0)
+
+	_, err = DiffMap(1, 2)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. input must be a struct")
+
+	_, err = DiffMap[any](1, before)
+	assertEqualTerminateTest(t, err.Error(), "type mismatch int != null.DiffTestEntity")
+
+	_, err = DiffMap[any](nil, nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, err = DiffMap(before, after, UseTag("db"), UseNullOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+}
@@ -0,0 +1,141 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// UpdateSet is the update document operator that sets the values of fields
+	UpdateSet = "$set"
+	// UpdateUnset is the update document operator that removes fields
+	UpdateUnset = "$unset"
+)
+
+type updateDocumentOpts struct {
+	filterOpts
+	setNull bool // if NULL values are set to null instead of being unset
+}
+
+// UseSetNull makes UpdateDocument set NULL values to null with $set instead of removing them with $unset
+func UseSetNull() optFunc[updateDocumentOpts] {
+	return func(o *updateDocumentOpts) {
+		o.setNull = true
+	}
+}
+
+// UpdateDocument creates a MongoDB style update document from the given struct.
+// Fields are named by their tags, which can be changed with the UseTag option.
+// The fields of nested Filterable structs and map[string]any values are named by
+// their paths in dot notation, e.g. address.city.
+//
+// Set values are put under $set and unset fields are left out, the same way as
+// in FilterStruct. NULL values are put under $unset, or under $set as nil with
+// the UseSetNull option. Operators without fields are left out of the document.
+func UpdateDocument(s any, opts ...option) (map[string]any, error) {
+	if s == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	sv := reflect.Indirect(reflect.ValueOf(s))
+	if sv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. input must be a struct", s)
+	}
+
+	// set options
+	fOpts, err := newOpts(updateDocumentOpts{filterOpts: defaultFilterOpts}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := updateDocument{
+		set:     make(map[string]any),
+		unset:   make(map[string]any),
+		setNull: fOpts.setNull,
+	}
+	doc.addStruct(fOpts.tag, "", sv)
+
+	return doc.build(), nil
+}
+
+// updateDocument collects the operations of an update document
+type updateDocument struct {
+	set     map[string]any
+	unset   map[string]any
+	setNull bool
+}
+
+// addStruct adds the fields of the given struct to the document
+func (d *updateDocument) addStruct(tag, prefix string, val reflect.Value) {
+	for _, f := range structFields(tag, val) {
+		fieldValue := f.value.Interface()
+		path := prefix + f.name
+
+		switch v := fieldValue.(type) {
+		case nullVar:
+			d.addNullVar(path, v)
+		case Filterable:
+			if f.value.Kind() != reflect.Struct {
+				d.set[path] = fieldValue
+				continue
+			}
+
+			// if embedded then the fields need to be on the same level as others
+			if f.embedded && f.name == "" {
+				d.addStruct(tag, prefix, f.value)
+				continue
+			}
+			d.addStruct(tag, path+".", f.value)
+		case map[string]any:
+			d.addMap(path+".", v)
+		default:
+			if f.name != "" {
+				d.set[path] = fieldValue
+			}
+		}
+	}
+}
+
+// addMap adds the keys of the given map to the document
+func (d *updateDocument) addMap(prefix string, m map[string]any) {
+	for k, v := range m {
+		path := prefix + k
+
+		switch val := v.(type) {
+		case nullVar:
+			d.addNullVar(path, val)
+		case map[string]any:
+			d.addMap(path+".", val)
+		default:
+			d.set[path] = v
+		}
+	}
+}
+
+// addNullVar adds a nullable variable to the document if it is set
+func (d *updateDocument) addNullVar(path string, v nullVar) {
+	if !v.isSet() {
+		return
+	}
+
+	val := v.getVal()
+	if val == nil && !d.setNull {
+		d.unset[path] = ""
+		return
+	}
+	d.set[path] = val
+}
+
+// build returns the document with the operators that have fields
+func (d *updateDocument) build() map[string]any {
+	doc := make(map[string]any)
+	if len(d.set) > 0 {
+		doc[UpdateSet] = d.set
+	}
+	if len(d.unset) > 0 {
+		doc[UpdateUnset] = d.unset
+	}
+
+	return doc
+}
@@ -0,0 +1,92 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUpdateDocument(t *testing.T) {
+	type Geo struct {
+		Filterable
+
+		Lat Var[float64] `json:"lat" bson:"lat"`
+		Lng Var[float64] `json:"lng"`
+	}
+
+	type Address struct {
+		Filterable
+
+		City Var[string] `json:"city" bson:"city"`
+		Zip  Var[string] `json:"zip"`
+		Geo  Geo         `json:"geo" bson:"geo"`
+	}
+
+	type Audit struct {
+		Filterable
+
+		UpdatedBy Var[string] `json:"updated_by"`
+	}
+
+	type User struct {
+		Filterable
+		Audit
+
+		Name    Var[string]    `json:"name" bson:"name"`
+		Email   Var[string]    `json:"email" bson:"email"`
+		Age     Var[int]       `json:"age"`
+		Version int            `json:"version"`
+		Address Address        `json:"address" bson:"address"`
+		Meta    map[string]any `json:"meta"`
+		Ignored Var[string]    `json:"-"`
+	}
+
+	u := User{
+		Audit:   Audit{UpdatedBy: From("admin")},
+		Name:    From("foo"),
+		Email:   Null[string](),
+		Version: 2,
+		Address: Address{
+			City: From("Budapest"),
+			Zip:  Null[string](),
+			Geo:  Geo{Lat: From(47.5)},
+		},
+		Meta: map[string]any{
+			"a": From(1),
+			"b": Null[int](),
+			"c": Unset[int](),
+			"d": map[string]any{
+				"e": "e",
+			},
+		},
+		Ignored: From("ignored"),
+	}
+
+	doc, err := UpdateDocument(u)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", doc), "map[$set:map[address.city:Budapest address.geo.lat:47.5 meta.a:1 meta.d.e:e name:foo updated_by:admin version:2] $unset:map[address.zip: email: meta.b:]]")
+
+	doc, err = UpdateDocument(&u, UseSetNull())
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", doc), "map[$set:map[address.city:Budapest address.geo.lat:47.5 address.zip:<nil> email:<nil> meta.a:1 meta.b:<nil> meta.d.e:e name:foo updated_by:admin version:2]]")
+
+	doc, err = UpdateDocument(u, UseTag("bson"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", doc), "map[$set:map[address.city:Budapest address.geo.lat:47.5 name:foo] $unset:map[email:]]")
+
+	doc, err = UpdateDocument(User{})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", doc), "map[$set:map[version:0]]")
+
+	doc, err = UpdateDocument(Address{})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, len(doc), 0)
+
+	_, err = UpdateDocument(nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, err = UpdateDocument(1)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. input must be a struct")
+
+	_, err = UpdateDocument(struct{}{}, UseSetNull(), UseChangeReport(nil))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+}
@@ -0,0 +1,89 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// defaultEnvOpts are the options of LoadEnv, which uses the env tag by default
+var defaultEnvOpts = valuesOpts{
+	filterOpts: filterOpts{tag: "env"},
+	nullValues: defaultValuesOpts.nullValues,
+}
+
+// LoadEnv loads environment variables into dest, which must be a pointer to a struct.
+// Variables are matched to fields by the env tag, which can be changed with the UseTag option.
+// The tag names of nested Filterable structs are prefixes of their fields joined with an underscore,
+// e.g. the host field of a db struct is loaded from DB_HOST.
+//
+// Fields are only set for variables that exist, so the values loaded before from other sources
+// are kept. Variables holding one of the strings set with UseNullValues set nullable fields to NULL,
+// other values are converted with the same rules as Scan. Slices are comma separated lists.
+// Fields tagged as required, e.g. `env:"PORT,required"`, must have a variable. Every missing
+// required variable and every conversion error is reported together in the returned error.
+func LoadEnv(dest any, opts ...option) error {
+	dv, err := scanDest(dest)
+	if err != nil {
+		return err
+	}
+
+	fOpts, err := newOpts(defaultEnvOpts, opts)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	errs := loadEnv(fOpts, "", dv, &missing)
+	if len(missing) > 0 {
+		errs = append([]error{fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", "))}, errs...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadEnv loads the environment variables of the fields of the given struct.
+// The names of missing required variables are collected in missing
+func loadEnv(fOpts valuesOpts, prefix string, val reflect.Value, missing *[]string) []error {
+	var errs []error
+
+	for _, f := range flatFields(fOpts.tag, val) {
+		name := prefix + f.name
+
+		if _, ok := f.value.Interface().(Filterable); ok && f.value.Kind() == reflect.Struct {
+			errs = append(errs, loadEnv(fOpts, name+"_", f.value, missing)...)
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			if slices.Contains(f.opts, "required") {
+				*missing = append(*missing, name)
+			}
+			continue
+		}
+
+		if err := decodeValues(f.value, envValues(f.value, value, fOpts.nullValues), fOpts.nullValues); err != nil {
+			errs = append(errs, fmt.Errorf("environment variable %q: %w", name, err))
+		}
+	}
+
+	return errs
+}
+
+// envValues splits the value of an environment variable into a list for slice fields
+func envValues(fv reflect.Value, value string, nullValues []string) []string {
+	valueType := fv.Type()
+	if nv, ok := fv.Interface().(nullVar); ok {
+		valueType = nv.valueType()
+	}
+
+	if valueType.Kind() != reflect.Slice || valueType.Elem().Kind() == reflect.Uint8 || slices.Contains(nullValues, value) {
+		return []string{value}
+	}
+
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,92 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLoadEnv(t *testing.T) {
+	type DB struct {
+		Filterable
+
+		Host Var[string] `env:"HOST,required"`
+		Port Var[int]    `env:"PORT"`
+	}
+
+	type Common struct {
+		Filterable
+
+		Debug Var[bool] `env:"DEBUG"`
+	}
+
+	type Config struct {
+		Filterable
+		Common
+
+		Name    Var[string]        `env:"NAME"`
+		Timeout Var[time.Duration] `env:"TIMEOUT"`
+		Hosts   Var[[]string]      `env:"HOSTS"`
+		Level   string             `env:"LEVEL"`
+		Limit   *int               `env:"LIMIT"`
+		DB      DB                 `env:"DB"`
+		Ignored Var[string]        `env:"-"`
+	}
+
+	t.Setenv("NAME", "svc")
+	t.Setenv("TIMEOUT", "")
+	t.Setenv("HOSTS", "a,b")
+	t.Setenv("LEVEL", "info")
+	t.Setenv("LIMIT", "10")
+	t.Setenv("DEBUG", "true")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_PORT", "5432")
+	t.Setenv("Ignored", "x")
+
+	c := Config{Timeout: From(time.Second), Ignored: From("kept")}
+	assertEqualTerminateTest(t, LoadEnv(&c), nil)
+	assertEqualTerminateTest(t, c.Name, From("svc"))
+	assertEqualTerminateTest(t, c.Timeout, Null[time.Duration]())
+	assertEqualTerminateTest(t, fmt.Sprintf("%q", c.Hosts.Val()), `["a" "b"]`)
+	assertEqualTerminateTest(t, c.Level, "info")
+	assertEqualTerminateTest(t, *c.Limit, 10)
+	assertEqualTerminateTest(t, c.Debug, From(true))
+	assertEqualTerminateTest(t, c.DB.Host, From("localhost"))
+	assertEqualTerminateTest(t, c.DB.Port, From(5432))
+	assertEqualTerminateTest(t, c.Ignored, From("kept"))
+
+	// variables that don't exist keep the previous values
+	t.Setenv("NAME", "other")
+	c = Config{Timeout: From(time.Second)}
+	t.Setenv("TIMEOUT", "null")
+	assertEqualTerminateTest(t, LoadEnv(&c, UseNullValues("null")), nil)
+	assertEqualTerminateTest(t, c.Name, From("other"))
+	assertEqualTerminateTest(t, c.Timeout, Null[time.Duration]())
+
+	c = Config{}
+	assertEqualTerminateTest(t, LoadEnv(&c, UseTag("other")), nil)
+	assertEqualTerminateTest(t, c.Name, Unset[string]())
+
+	// errors are reported together
+	type Required struct {
+		A Var[string] `env:"NULL_TEST_A,required"`
+		B Var[int]    `env:"NULL_TEST_B"`
+		C Var[int]    `env:"NULL_TEST_C"`
+		D Var[string] `env:"NULL_TEST_D,required"`
+		E DB          `env:"NULL_TEST_E"`
+	}
+	t.Setenv("NULL_TEST_B", "foo")
+	t.Setenv("NULL_TEST_C", "bar")
+
+	var r Required
+	err := LoadEnv(&r)
+	assertEqualTerminateTest(t, err.Error(), "missing required environment variables: NULL_TEST_A, NULL_TEST_D, NULL_TEST_E_HOST\n"+
+		`environment variable "NULL_TEST_B": converting driver.Value type string ("foo") to a int: invalid syntax`+"\n"+
+		`environment variable "NULL_TEST_C": converting driver.Value type string ("bar") to a int: invalid syntax`)
+
+	err = LoadEnv(r)
+	assertEqualTerminateTest(t, err.Error(), "invalid type null.Required. destination must be a non-nil pointer to a struct")
+
+	err = LoadEnv(&r, UseNullValues("null"), UseNullOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+}
@@ -12,7 +12,22 @@ type (
 		tag string
 	}
 
-	filterOpt func(f *filterOpts)
+	// option is an option of the helpers of the package. Every helper accepts the
+	// options of FilterStruct, e.g. UseTag, while the other options are only accepted
+	// by the helper they are made for. Other options make the helpers return an error
+	option interface {
+		apply(o any) bool
+	}
+
+	// optFunc is an option that sets the options O of a helper
+	optFunc[O any] func(o *O)
+
+	// Field is a struct field selected by StructFields
+	Field struct {
+		Name    string        // the name given in the tag
+		Options []string      // the tag options following the name
+		Value   reflect.Value // the value of the field
+	}
 )
 
 var (
@@ -22,7 +37,7 @@ var (
 )
 
 // UseTag
-func UseTag(tag string) filterOpt {
+func UseTag(tag string) optFunc[filterOpts] {
 	if tag == "" {
 		return func(f *filterOpts) {}
 	}
@@ -32,8 +47,43 @@ func UseTag(tag string) filterOpt {
 	}
 }
 
+// filterOptions returns the options of FilterStruct. It is promoted to the options of
+// the other helpers that embed them, so they accept the options of FilterStruct as well
+func (f *filterOpts) filterOptions() *filterOpts {
+	return f
+}
+
+// apply implements the option interface. It reports if o are the options of the helper
+func (f optFunc[O]) apply(o any) bool {
+	if opts, ok := o.(*O); ok {
+		f(opts)
+		return true
+	}
+
+	if fo, ok := o.(interface{ filterOptions() *filterOpts }); ok {
+		if opts, ok := any(fo.filterOptions()).(*O); ok {
+			f(opts)
+			return true
+		}
+	}
+
+	return false
+}
+
+// newOpts applies the given options on the defaults of a helper.
+// It returns an error if an option is made for another helper
+func newOpts[O any](defaults O, opts []option) (O, error) {
+	for i, opt := range opts {
+		if !opt.apply(&defaults) {
+			return defaults, fmt.Errorf("unsupported option at index %d", i)
+		}
+	}
+
+	return defaults, nil
+}
+
 // FilterStruct filters the given structure from unset nullable fields
-func FilterStruct(s any, opts ...filterOpt) (map[string]any, error) {
+func FilterStruct(s any, opts ...option) (map[string]any, error) {
 	if s == nil {
 		return nil, errors.New("input cannot be nil")
 	}
@@ -43,10 +93,9 @@ func FilterStruct(s any, opts ...filterOpt) (map[string]any, error) {
 		return nil, fmt.Errorf("invalid type %T. input must be a struct", s)
 	}
 
-	// set options
-	fOpts := defaultFilterOpts
-	for _, opt := range opts {
-		opt(&fOpts)
+	fOpts, err := newOpts(defaultFilterOpts, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	retMap := filterStruct(fOpts.tag, s)
@@ -62,6 +111,44 @@ func FilterMap(m map[string]any) (map[string]any, error) {
 	return filterMap(m), nil
 }
 
+// StructFields returns the fields of the given struct, or pointer to a struct,
+// in declaration order. It makes it possible to build other encoders on top of
+// nullable fields, e.g. the csv subpackage.
+//
+// Fields are selected by the tag set with the UseTag option the same way as in
+// FilterStruct, so unexported fields, fields without the tag and fields tagged
+// with "-" are skipped. The fields of embedded Filterable structs without a tag
+// name are promoted, unless a field with the same name already exists, while
+// other embedded structs without a tag name are skipped.
+//
+// The values of the fields can only be set if v is a pointer.
+// It returns nil if v is neither a struct nor a non-nil pointer to one,
+// and panics if an option is not an option of FilterStruct.
+func StructFields(v reflect.Value, opts ...option) []Field {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// set options
+	fOpts, err := newOpts(defaultFilterOpts, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	flat := flatFields(fOpts.tag, v)
+	fields := make([]Field, len(flat))
+	for i, f := range flat {
+		fields[i] = Field{
+			Name:    f.name,
+			Options: f.opts,
+			Value:   f.value,
+		}
+	}
+
+	return fields
+}
+
 // filterMap filters a map from unset nullable variables.
 // If keepOtherFields is true, then every other field that is not a nullable type will keep intact
 func filterMap(m map[string]any) map[string]any {
@@ -88,26 +175,21 @@ func filterMap(m map[string]any) map[string]any {
 	return retMap
 }
 
-// 1. loop through struct fields
-// 2. check each field
-// a. unexported -> continue
-// b. doesn't have the necessary tag -> continue
-// c. struct and implements Filterable -> filterStruct
-// d. struct and doesn't implement filterable -> use as is
-// e. map[string]any -> filterMap
-// f. anonymous
-// 	i. struct -> filterStruct
-// 	ii. map[string]any -> filterMap
-
-// structFieldsToMap creates a map from the given struct via the assigned tags.
-func filterStruct(tag string, s any) map[string]any {
-	retMap := make(map[string]any)
+// taggedField is a struct field selected by structFields
+type taggedField struct {
+	name     string              // the name given in the tag, empty for untagged embedded structs
+	opts     []string            // the tag options following the name
+	embedded bool                // if the field is embedded
+	field    reflect.StructField // the struct field itself
+	value    reflect.Value       // its value
+}
 
-	if s == nil {
-		return retMap
-	}
+// structFields returns the fields of the given struct value that should be
+// handled according to the given tag. Unexported fields, fields without the
+// tag (unless they are embedded structs) and fields tagged with "-" are skipped.
+func structFields(tag string, val reflect.Value) []taggedField {
+	fields := []taggedField{}
 
-	val := reflect.ValueOf(s)
 	for i := 0; i < val.NumField(); i++ {
 
 		// skip unexported fields
@@ -117,7 +199,6 @@ func filterStruct(tag string, s any) map[string]any {
 
 		structField := val.Type().Field(i)       // the struct field itself
 		fieldKind := structField.Type.Kind()     // its kind
-		fieldValue := val.Field(i).Interface()   // its value as an interface
 		fieldIsEmbedded := structField.Anonymous // if its embedded
 		fieldName := ""                          // default name
 
@@ -142,6 +223,43 @@ func filterStruct(tag string, s any) map[string]any {
 			continue
 		}
 
+		fields = append(fields, taggedField{
+			name:     fieldName,
+			opts:     tagOpts[1:],
+			embedded: fieldIsEmbedded,
+			field:    structField,
+			value:    val.Field(i),
+		})
+	}
+
+	return fields
+}
+
+// 1. loop through struct fields
+// 2. check each field
+// a. unexported -> continue
+// b. doesn't have the necessary tag -> continue
+// c. struct and implements Filterable -> filterStruct
+// d. struct and doesn't implement filterable -> use as is
+// e. map[string]any -> filterMap
+// f. anonymous
+// 	i. struct -> filterStruct
+// 	ii. map[string]any -> filterMap
+
+// structFieldsToMap creates a map from the given struct via the assigned tags.
+func filterStruct(tag string, s any) map[string]any {
+	retMap := make(map[string]any)
+
+	if s == nil {
+		return retMap
+	}
+
+	for _, f := range structFields(tag, reflect.ValueOf(s)) {
+		fieldKind := f.field.Type.Kind()  // its kind
+		fieldValue := f.value.Interface() // its value as an interface
+		fieldIsEmbedded := f.embedded     // if its embedded
+		fieldName := f.name               // its name
+
 		switch fieldKind {
 		case reflect.Struct:
 			// check if implements Filterable
@@ -2,6 +2,7 @@ package null
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -46,6 +47,10 @@ func TestFilterStruct(t *testing.T) {
 	_, err = FilterStruct(int64(1))
 	assertEqualTerminateTest(t, err.Error(), "invalid type int64. input must be a struct")
 
+	// options of other helpers are not supported
+	_, err = FilterStruct(struct{}{}, UseTag("db"), UseSetOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+
 	expectDef := map[string]any{
 		"other_str":  "",
 		"some_field": "",
@@ -121,14 +126,12 @@ func TestFilterStruct(t *testing.T) {
 
 	def2 := S5{
 		A: Var[string]{
-			set:   true,
-			valid: false,
+			state: StateNull,
 			value: "",
 		},
 		M: map[string]any{
 			"z": Var[string]{
-				set:   true,
-				valid: true,
+				state: StateValue,
 				value: "z",
 			},
 			"zz":  Var[string]{},
@@ -136,13 +139,11 @@ func TestFilterStruct(t *testing.T) {
 			"mm": map[string]any{
 				"q": "",
 				"qq": Var[float64]{
-					set:   true,
-					valid: true,
+					state: StateValue,
 					value: 0.2,
 				},
 				"qqq": Var[float64]{
-					set:   true,
-					valid: false,
+					state: StateNull,
 					value: 0,
 				},
 			},
@@ -156,13 +157,11 @@ func TestFilterStruct(t *testing.T) {
 		},
 		S: S4{
 			C: Var[int64]{
-				set:   false,
-				valid: false,
+				state: StateUnset,
 				value: 0,
 			},
 			D: Var[int64]{
-				set:   true,
-				valid: false,
+				state: StateNull,
 				value: 0,
 			},
 		},
@@ -183,13 +182,11 @@ func TestFilterMap(t *testing.T) {
 
 	m := map[string]any{
 		"a": Var[string]{
-			set:   true,
-			valid: false,
+			state: StateNull,
 			value: "",
 		},
 		"b": Var[string]{
-			set:   true,
-			valid: true,
+			state: StateValue,
 			value: "B",
 		},
 		"c": Var[string]{},
@@ -199,13 +196,11 @@ func TestFilterMap(t *testing.T) {
 		},
 		"f": map[string]any{
 			"a": Var[string]{
-				set:   true,
-				valid: false,
+				state: StateNull,
 				value: "",
 			},
 			"b": Var[string]{
-				set:   true,
-				valid: true,
+				state: StateValue,
 				value: "B",
 			},
 		},
@@ -231,3 +226,65 @@ func TestFilterMap(t *testing.T) {
 	assertEqualTerminateTest(t, err == nil, true)
 	assertEqualTerminateTest(t, fmt.Sprintf("%+v", mExpect), fmt.Sprintf("%+v", mFiltered))
 }
+
+func TestStructFields(t *testing.T) {
+	type Base struct {
+		Filterable
+
+		ID   int64       `json:"id"`
+		Name Var[string] `json:"name"`
+	}
+
+	type S struct {
+		Filterable
+		Base
+
+		Name  Var[string] `json:"name,omitempty"`
+		Other string      `json:"-"`
+		Age   Var[int]    `json:"age"`
+	}
+
+	s := S{Name: From("outer")}
+	got := ""
+	for _, f := range StructFields(reflect.ValueOf(&s)) {
+		got += fmt.Sprintf("%s%v=%v ", f.Name, f.Options, f.Value.Interface())
+	}
+	assertEqualTerminateTest(t, got, "id[]=0 name[omitempty]={2 outer} age[]={0 0} ")
+
+	assertEqualTerminateTest(t, len(StructFields(reflect.ValueOf(s), UseTag("db"))), 0)
+	assertEqualTerminateTest(t, StructFields(reflect.ValueOf(1)) == nil, true)
+	assertEqualTerminateTest(t, StructFields(reflect.ValueOf((*S)(nil))) == nil, true)
+	assertEqualTerminateTest(t, StructFields(reflect.Value{}) == nil, true)
+
+	// the values can be set through a pointer only
+	fields := StructFields(reflect.ValueOf(&s))
+	assertEqualTerminateTest(t, fields[2].Value.CanSet(), true)
+	fields[2].Value.Set(reflect.ValueOf(From(42)))
+	assertEqualTerminateTest(t, s.Age, From(42))
+	assertEqualTerminateTest(t, StructFields(reflect.ValueOf(s))[2].Value.CanSet(), false)
+
+	type Plain struct {
+		A string `db:"a"`
+	}
+
+	type T struct {
+		Plain
+		Base    `db:"base"`
+		private string `db:"private"`
+		NoTag   string
+		Skipped string `db:"-"`
+		Empty   string `db:""`
+		Code    string `db:"code,pk"`
+	}
+
+	got = ""
+	for _, f := range StructFields(reflect.ValueOf(T{Code: "x"}), UseTag("db")) {
+		got += fmt.Sprintf("%s%v=%v ", f.Name, f.Options, f.Value.Interface())
+	}
+	assertEqualTerminateTest(t, got, "base[]={<nil> 0 {0 }} code[pk]=x ")
+
+	defer func() {
+		assertEqualTerminateTest(t, fmt.Sprint(recover()), "unsupported option at index 1")
+	}()
+	StructFields(reflect.ValueOf(T{}), UseTag("db"), UseSetOp(PatchOpAdd))
+}
@@ -0,0 +1,158 @@
+package null
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type (
+	// Flag adapts a Var to the flag.Value and flag.Getter interfaces.
+	// The Var stays unset until the flag is passed on the command line
+	Flag[T any] struct {
+		v   *Var[T]
+		set bool // if the flag was set already
+	}
+
+	// an internal interface that helps registering nullable variables as flags
+	flagVar interface {
+		flagValue() flag.Value
+	}
+)
+
+// defaultFlagOpts are the options of FlagSet, which uses the flag tag by default
+var defaultFlagOpts = filterOpts{tag: "flag"}
+
+// FlagOf returns a flag.Value that sets v when the flag is passed
+func FlagOf[T any](v *Var[T]) *Flag[T] {
+	return &Flag[T]{v: v}
+}
+
+// String implements the flag.Value interface.
+// Unset and NULL values are formatted as an empty string
+func (f *Flag[T]) String() string {
+	if f == nil || f.v == nil || f.v.state != StateValue {
+		return ""
+	}
+
+	if d, ok := any(f.v.value).(time.Duration); ok {
+		return d.String()
+	}
+
+	if b, err := f.v.MarshalText(); err == nil {
+		return string(b)
+	}
+
+	return fmt.Sprint(f.v.value)
+}
+
+// Set implements the flag.Value interface. The value is decoded by its own
+// UnmarshalText method if it has one and durations are parsed with
+// time.ParseDuration, otherwise it is converted with the same rules as Scan.
+// The first occurrence of a slice flag replaces the value of the Var and the
+// next ones are appended to it, so the flag can be repeated
+func (f *Flag[T]) Set(s string) error {
+	if f == nil || f.v == nil {
+		return errNilPtr
+	}
+
+	var value T
+	rv := reflect.ValueOf(&value).Elem()
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		if err := parseFlag(rv, s); err != nil {
+			return err
+		}
+		f.v.Set(value)
+		return nil
+	}
+
+	// every occurrence of the flag adds a new element
+	elem := reflect.New(rv.Type().Elem()).Elem()
+	if err := parseFlag(elem, s); err != nil {
+		return err
+	}
+	if f.set && f.v.state == StateValue {
+		value = f.v.value
+	}
+	f.v.Set(reflect.Append(reflect.ValueOf(value), elem).Interface().(T))
+	f.set = true
+	return nil
+}
+
+// Get implements the flag.Getter interface.
+// It returns nil if the value is unset or NULL
+func (f *Flag[T]) Get() any {
+	if f == nil || f.v == nil {
+		return nil
+	}
+
+	return f.v.getVal()
+}
+
+// IsBoolFlag makes boolean flags possible to pass without a value
+func (f *Flag[T]) IsBoolFlag() bool {
+	var def T
+	return reflect.ValueOf(&def).Elem().Kind() == reflect.Bool
+}
+
+// flagValue implements the flagVar interface for internal usage
+func (v *Var[T]) flagValue() flag.Value {
+	return FlagOf(v)
+}
+
+// FlagSet registers the nullable fields of dest as flags of fs. If fs is nil, then
+// flag.CommandLine is used. dest must be a pointer to a struct. Fields are named by the
+// flag tag, which can be changed with the UseTag option, and the usage message is taken
+// from the usage tag. Fields whose flags are not passed remain unset after parsing.
+func FlagSet(fs *flag.FlagSet, dest any, opts ...option) error {
+	dv, err := scanDest(dest)
+	if err != nil {
+		return err
+	}
+
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	// set options
+	fOpts, err := newOpts(defaultFlagOpts, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range flatFields(fOpts.tag, dv) {
+		fv, ok := f.value.Addr().Interface().(flagVar)
+		if !ok {
+			return fmt.Errorf("invalid type %s of flag %q. flags must be nullable", f.field.Type, f.name)
+		}
+
+		if fs.Lookup(f.name) != nil {
+			return errors.New("flag redefined: " + f.name)
+		}
+
+		fs.Var(fv.flagValue(), f.name, f.field.Tag.Get("usage"))
+	}
+
+	return nil
+}
+
+// parseFlag parses s into rv. Durations are parsed with time.ParseDuration
+func parseFlag(rv reflect.Value, s string) error {
+	if d, ok := rv.Addr().Interface().(*time.Duration); ok {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*d = v
+		return nil
+	}
+
+	if tu, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(s))
+	}
+
+	return convertAssign(rv.Addr().Interface(), s)
+}
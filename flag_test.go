@@ -0,0 +1,119 @@
+package null
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	var timeout Var[time.Duration]
+	var retries Var[int]
+	var verbose Var[bool]
+	var tags Var[[]string]
+	var since Var[time.Time]
+	fs.Var(FlagOf(&timeout), "timeout", "")
+	fs.Var(FlagOf(&retries), "retries", "")
+	fs.Var(FlagOf(&verbose), "verbose", "")
+	fs.Var(FlagOf(&tags), "tag", "")
+	fs.Var(FlagOf(&since), "since", "")
+
+	err := fs.Parse([]string{"-timeout", "1.5s", "-verbose", "-tag", "a", "-tag=b", "-since", "2023-01-02T03:04:05Z"})
+	assertEqualTerminateTest(t, err, nil)
+
+	assertEqualTerminateTest(t, timeout, From(1500*time.Millisecond))
+	assertEqualTerminateTest(t, retries, Unset[int]())
+	assertEqualTerminateTest(t, verbose, From(true))
+	assertEqualTerminateTest(t, fmt.Sprintf("%q", tags.Val()), `["a" "b"]`)
+	assertEqualTerminateTest(t, since.Val().Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), true)
+
+	// flag.Getter
+	assertEqualTerminateTest(t, fs.Lookup("timeout").Value.(flag.Getter).Get(), any(1500*time.Millisecond))
+	assertEqualTerminateTest(t, fs.Lookup("retries").Value.(flag.Getter).Get(), nil)
+
+	assertEqualTerminateTest(t, fs.Lookup("timeout").Value.String(), "1.5s")
+	assertEqualTerminateTest(t, fs.Lookup("verbose").Value.String(), "true")
+	assertEqualTerminateTest(t, fs.Lookup("tag").Value.String(), "[a b]")
+	assertEqualTerminateTest(t, fs.Lookup("retries").Value.String(), "")
+
+	fs.SetOutput(io.Discard)
+	err = fs.Parse([]string{"-timeout", "1500"})
+	assertEqualTerminateTest(t, err.Error(), `invalid value "1500" for flag -timeout: time: missing unit in duration "1500"`)
+
+	err = fs.Parse([]string{"-retries", "foo"})
+	assertEqualTerminateTest(t, err.Error(), `invalid value "foo" for flag -retries: converting driver.Value type string ("foo") to a int: invalid syntax`)
+
+	assertEqualTerminateTest(t, (&Flag[int]{}).Set("1"), errNilPtr)
+	assertEqualTerminateTest(t, (&Flag[int]{}).Get(), nil)
+	assertEqualTerminateTest(t, (&Flag[int]{}).String(), "")
+	assertEqualTerminateTest(t, (*Flag[int])(nil).Set("1"), errNilPtr)
+	assertEqualTerminateTest(t, (*Flag[int])(nil).Get(), nil)
+
+	// the first occurrence replaces the value loaded before, e.g. from a config file
+	configTags := From([]string{"config"})
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(FlagOf(&configTags), "tag", "")
+	assertEqualTerminateTest(t, fs.Parse([]string{"-tag", "a", "-tag", "b"}), nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%q", configTags.Val()), `["a" "b"]`)
+}
+
+func TestFlagSet(t *testing.T) {
+	type Limits struct {
+		Filterable
+
+		Retries Var[int] `flag:"retries" usage:"number of retries"`
+	}
+
+	type Config struct {
+		Filterable
+		Limits
+
+		Name    Var[string] `flag:"name" usage:"name of the service"`
+		Port    Var[int]    `flag:"port" env:"PORT"`
+		Debug   Var[bool]   `flag:"debug"`
+		Ignored Var[int]    `flag:"-"`
+		Other   string
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := Config{Port: From(8080)}
+	assertEqualTerminateTest(t, FlagSet(fs, &c), nil)
+
+	err := fs.Parse([]string{"-name", "svc", "-debug", "-retries=3"})
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, c.Name, From("svc"))
+	assertEqualTerminateTest(t, c.Port, From(8080))
+	assertEqualTerminateTest(t, c.Debug, From(true))
+	assertEqualTerminateTest(t, c.Retries, From(3))
+	assertEqualTerminateTest(t, c.Ignored, Unset[int]())
+
+	// defaults and usage are printed
+	out := &strings.Builder{}
+	fs.SetOutput(out)
+	fs.PrintDefaults()
+	assertEqualTerminateTest(t, strings.Contains(out.String(), "-name value\n    \tname of the service"), true)
+	assertEqualTerminateTest(t, strings.Contains(out.String(), "-port value\n    \t (default 8080)"), true)
+
+	assertEqualTerminateTest(t, FlagSet(fs, &c).Error(), "flag redefined: retries")
+
+	type Invalid struct {
+		Port int `flag:"port"`
+	}
+	assertEqualTerminateTest(t, FlagSet(fs, &Invalid{}).Error(), `invalid type int of flag "port". flags must be nullable`)
+
+	assertEqualTerminateTest(t, FlagSet(fs, c).Error(), "invalid type null.Config. destination must be a non-nil pointer to a struct")
+
+	// custom tag
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	c = Config{}
+	assertEqualTerminateTest(t, FlagSet(fs, &c, UseTag("env")), nil)
+	assertEqualTerminateTest(t, fs.Parse([]string{"-PORT", "1"}), nil)
+	assertEqualTerminateTest(t, c.Port, From(1))
+
+	assertEqualTerminateTest(t, FlagSet(fs, &c, UseNullValues()).Error(), "unsupported option at index 0")
+}
@@ -0,0 +1,60 @@
+package null
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// GobEncode implements the gob.GobEncoder interface.
+// The state is encoded as a single byte, followed by the gob encoded value if there is one
+func (v Var[T]) GobEncode() ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{byte(v.state)})
+	if v.state != StateValue {
+		return buf.Bytes(), nil
+	}
+
+	if err := gob.NewEncoder(buf).Encode(v.value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface
+func (v *Var[T]) GobDecode(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("gob: missing state")
+	}
+
+	switch state := State(data[0]); state {
+	case StateUnset:
+		v.Unset()
+		return nil
+	case StateNull:
+		v.SetNil()
+		return nil
+	case StateValue:
+		var value T
+		if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&value); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	default:
+		return fmt.Errorf("gob: invalid state %d", state)
+	}
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It uses the same encoding as GobEncode
+func (v Var[T]) MarshalBinary() ([]byte, error) {
+	return v.GobEncode()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It uses the same encoding as GobDecode
+func (v *Var[T]) UnmarshalBinary(data []byte) error {
+	return v.GobDecode(data)
+}
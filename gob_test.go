@@ -0,0 +1,80 @@
+package null
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+func TestGob(t *testing.T) {
+	type Sibling struct {
+		Name Var[string] `json:"name"`
+	}
+
+	type Person struct {
+		Name      Var[string]
+		Age       Var[int64]
+		Email     Var[string]
+		BirthDate Var[time.Time]
+		Tags      Var[[]string]
+		Sibling   Var[Sibling]
+	}
+
+	birth := time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Person{
+		Name:      From("Peter"),
+		Age:       Null[int64](),
+		BirthDate: From(birth),
+		Tags:      From([]string{"a", "b"}),
+		Sibling:   From(Sibling{Name: From("Anna")}),
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(p)
+	assertEqualTerminateTest(t, err == nil, true)
+
+	var got Person
+	err = gob.NewDecoder(&buf).Decode(&got)
+	assertEqualTerminateTest(t, err == nil, true)
+
+	if err := checkVar(t, got.Name, true, true, "Peter"); err != nil {
+		t.Errorf("[name] %v", err)
+	}
+	if err := checkVar(t, got.Age, true, false, 0); err != nil {
+		t.Errorf("[age] %v", err)
+	}
+	if err := checkVar(t, got.Email, false, false, ""); err != nil {
+		t.Errorf("[email] %v", err)
+	}
+	assertEqualTerminateTest(t, got.BirthDate.Val().Equal(birth), true)
+	assertEqualTerminateTest(t, len(got.Tags.Val()), 2)
+	if err := checkVar(t, got.Sibling.Val().Name, true, true, "Anna"); err != nil {
+		t.Errorf("[sibling] %v", err)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	tests := []Var[int]{From(42), From(0), Null[int](), Unset[int]()}
+
+	for n, tc := range tests {
+		b, err := tc.MarshalBinary()
+		if err != nil {
+			t.Errorf("testCase #%v: %v", n, err)
+			continue
+		}
+		assertEqualTerminateTest(t, State(b[0]), tc.State())
+
+		got := From(1)
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Errorf("testCase #%v: %v", n, err)
+			continue
+		}
+		assertEqualTerminateTest(t, got, tc)
+	}
+
+	var v Var[int]
+	assertEqualTerminateTest(t, v.UnmarshalBinary(nil).Error(), "gob: missing state")
+	assertEqualTerminateTest(t, v.UnmarshalBinary([]byte{3}).Error(), "gob: invalid state 3")
+	assertEqualTerminateTest(t, v.UnmarshalBinary([]byte{byte(StateValue)}).Error(), "EOF")
+}
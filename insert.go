@@ -0,0 +1,233 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// BuildInsert builds an INSERT statement for the given table. rows can be a
+// struct, a pointer to one or a slice of them. Column names are taken from the
+// db tag, which can be changed with the UseTag option. The placeholder style and
+// identifier quoting can be set with the UseDialect option.
+//
+// Unset nullable fields are left out, so that the column DEFAULT applies, while
+// NULL fields insert an explicit NULL. When rows set different columns the
+// missing ones are filled with DEFAULT, which is not supported by DialectSQLite.
+//
+// The returned arguments are the nullable variables themselves, so that
+// Var.Value handles NULL values.
+func BuildInsert(table string, rows any, opts ...option) (string, []any, error) {
+	fOpts, err := newOpts(defaultSQLBuilderOpts, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	b, _, err := buildInsert(fOpts, table, rows)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return b.String(), b.args, nil
+}
+
+// BuildUpsert builds an INSERT statement the same way as BuildInsert, that
+// updates the existing row on conflict. It uses ON CONFLICT ... DO UPDATE with
+// DialectPostgres and DialectSQLite and ON DUPLICATE KEY UPDATE with DialectMySQL.
+//
+// The conflict columns define the conflict target. They are not used by
+// DialectMySQL, which relies on the unique keys of the table instead. Only
+// the columns set in every row are updated, apart from the conflict columns.
+func BuildUpsert(table string, rows any, conflict []string, opts ...option) (string, []any, error) {
+	fOpts, err := newOpts(defaultSQLBuilderOpts, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if fOpts.dialect.upsert == upsertNone {
+		return "", nil, errors.New("dialect does not support upserts")
+	}
+
+	if fOpts.dialect.upsert == upsertOnConflict && len(conflict) == 0 {
+		return "", nil, errors.New("conflict columns cannot be empty")
+	}
+
+	b, columns, err := buildInsert(fOpts, table, rows)
+	if err != nil {
+		return "", nil, err
+	}
+
+	isConflict := make(map[string]bool)
+	for _, c := range conflict {
+		isConflict[c] = true
+	}
+
+	update := []string{}
+	for _, c := range columns {
+		if !isConflict[c] {
+			update = append(update, c)
+		}
+	}
+
+	switch fOpts.dialect.upsert {
+	case upsertOnConflict:
+		b.write(" ON CONFLICT (")
+		for i, c := range conflict {
+			if i > 0 {
+				b.write(", ")
+			}
+			b.ident(c)
+		}
+		b.write(")")
+
+		if len(update) == 0 {
+			b.write(" DO NOTHING")
+			break
+		}
+
+		b.write(" DO UPDATE SET ")
+		for i, c := range update {
+			if i > 0 {
+				b.write(", ")
+			}
+			b.ident(c)
+			b.write(" = EXCLUDED.")
+			b.ident(c)
+		}
+
+	case upsertOnDuplicateKey:
+		// a no-op assignment keeps the existing row if there is nothing to update
+		if len(update) == 0 && len(columns) > 0 {
+			update = columns[:1]
+		}
+		if len(update) == 0 {
+			return "", nil, errors.New("no columns to update")
+		}
+
+		b.write(" ON DUPLICATE KEY UPDATE ")
+		for i, c := range update {
+			if i > 0 {
+				b.write(", ")
+			}
+			b.ident(c)
+			b.write(" = VALUES(")
+			b.ident(c)
+			b.write(")")
+		}
+	}
+
+	return b.String(), b.args, nil
+}
+
+// buildInsert writes the INSERT statement of the given rows and returns
+// the columns that were set in every row
+func buildInsert(fOpts sqlBuilderOpts, table string, rows any) (*sqlBuilder, []string, error) {
+	values, err := structRows(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// collect the columns of every row in the order of their first appearance
+	columns := []string{}
+	counts := make(map[string]int)
+	rowColumns := make([]map[string]any, len(values))
+	for i, v := range values {
+		rowColumns[i] = make(map[string]any)
+		for _, c := range sqlColumns(fOpts.tag, v) {
+			if counts[c.name] == 0 {
+				columns = append(columns, c.name)
+			}
+			counts[c.name]++
+			rowColumns[i][c.name] = c.value
+		}
+	}
+
+	common := []string{}
+	for _, c := range columns {
+		if counts[c] == len(values) {
+			common = append(common, c)
+		}
+	}
+
+	if len(common) != len(columns) && fOpts.dialect.noDefault {
+		return nil, nil, errors.New("dialect does not support DEFAULT values, every row must set the same columns")
+	}
+
+	b := newSQLBuilder(fOpts.dialect)
+	b.write("INSERT INTO ")
+	b.ident(table)
+
+	if len(columns) == 0 {
+		if len(values) > 1 {
+			return nil, nil, errors.New("no columns to insert")
+		}
+
+		if fOpts.dialect.upsert == upsertOnDuplicateKey {
+			b.write(" () VALUES ()")
+		} else {
+			b.write(" DEFAULT VALUES")
+		}
+		return b, common, nil
+	}
+
+	b.write(" (")
+	for i, c := range columns {
+		if i > 0 {
+			b.write(", ")
+		}
+		b.ident(c)
+	}
+	b.write(") VALUES ")
+
+	for i, rc := range rowColumns {
+		if i > 0 {
+			b.write(", ")
+		}
+
+		b.write("(")
+		for j, c := range columns {
+			if j > 0 {
+				b.write(", ")
+			}
+
+			v, ok := rc[c]
+			if !ok {
+				b.write("DEFAULT")
+				continue
+			}
+			b.bind(c, v)
+		}
+		b.write(")")
+	}
+
+	return b, common, nil
+}
+
+// structRows returns the structs of the given struct, pointer or slice
+func structRows(rows any) ([]reflect.Value, error) {
+	if rows == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(rows))
+	switch rv.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{rv}, nil
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return nil, errors.New("no rows to insert")
+		}
+
+		values := make([]reflect.Value, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			v := reflect.Indirect(rv.Index(i))
+			if v.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("invalid type %T. input must be a struct or a slice of structs", rows)
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("invalid type %T. input must be a struct or a slice of structs", rows)
+}
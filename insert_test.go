@@ -0,0 +1,112 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBuildInsert(t *testing.T) {
+	type S struct {
+		ID    int64       `db:"id"`
+		Name  Var[string] `db:"name"`
+		Email Var[string] `db:"email"`
+	}
+
+	_, _, err := BuildInsert("users", nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, _, err = BuildInsert("users", 1)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. input must be a struct or a slice of structs")
+
+	_, _, err = BuildInsert("users", []int{1})
+	assertEqualTerminateTest(t, err.Error(), "invalid type []int. input must be a struct or a slice of structs")
+
+	_, _, err = BuildInsert("users", []S{})
+	assertEqualTerminateTest(t, err.Error(), "no rows to insert")
+
+	// unset fields are left out, NULL fields are inserted
+	s := S{ID: 1, Email: Null[string]()}
+	query, args, err := BuildInsert("users", &s, UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO "users" ("id", "email") VALUES ($1, $2)`)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", args), fmt.Sprintf("%+v", []any{int64(1), s.Email}))
+
+	// rows with different columns
+	rows := []S{
+		{ID: 1, Name: From("Peter")},
+		{ID: 2, Email: From("anna@example.com")},
+	}
+	query, args, err = BuildInsert("users", rows)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO users (id, name, email) VALUES (?, ?, DEFAULT), (?, DEFAULT, ?)`)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", args), fmt.Sprintf("%+v", []any{int64(1), rows[0].Name, int64(2), rows[1].Email}))
+
+	query, _, err = BuildInsert("users", []*S{&rows[0], &rows[0]}, UseDialect(DialectOracle))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO "users" ("id", "name") VALUES (:id, :name), (:id_2, :name_2)`)
+
+	_, _, err = BuildInsert("users", rows, UseDialect(DialectSQLite))
+	assertEqualTerminateTest(t, err.Error(), "dialect does not support DEFAULT values, every row must set the same columns")
+
+	// no columns at all
+	type E struct {
+		Name Var[string] `db:"name"`
+	}
+
+	query, _, err = BuildInsert("users", E{}, UseDialect(DialectSQLite))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO "users" DEFAULT VALUES`)
+
+	query, _, err = BuildInsert("users", E{}, UseDialect(DialectMySQL))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, "INSERT INTO `users` () VALUES ()")
+
+	_, _, err = BuildInsert("users", []E{{}, {}})
+	assertEqualTerminateTest(t, err.Error(), "no columns to insert")
+}
+
+func TestBuildUpsert(t *testing.T) {
+	type S struct {
+		ID    int64       `db:"id"`
+		Name  Var[string] `db:"name"`
+		Email Var[string] `db:"email"`
+	}
+
+	rows := []S{
+		{ID: 1, Name: From("Peter"), Email: Null[string]()},
+		{ID: 2, Name: From("Anna")},
+	}
+
+	_, _, err := BuildUpsert("users", rows, []string{"id"})
+	assertEqualTerminateTest(t, err.Error(), "dialect does not support upserts")
+
+	_, _, err = BuildUpsert("users", rows, nil, UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err.Error(), "conflict columns cannot be empty")
+
+	_, _, err = BuildUpsert("users", rows, []string{"id"}, UseDialect(DialectPostgres), UseNullOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+
+	_, _, err = BuildInsert("users", rows, UseNullOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+
+	query, args, err := BuildUpsert("users", rows, []string{"id"}, UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO "users" ("id", "name", "email") VALUES ($1, $2, $3), ($4, $5, DEFAULT) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`)
+	assertEqualTerminateTest(t, len(args), 5)
+
+	query, _, err = BuildUpsert("users", rows[0], []string{"id"}, UseDialect(DialectSQLite))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO "users" ("id", "name", "email") VALUES (?, ?, ?) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "email" = EXCLUDED."email"`)
+
+	query, _, err = BuildUpsert("users", S{ID: 1}, []string{"id"}, UseDialect(DialectSQLite))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `INSERT INTO "users" ("id") VALUES (?) ON CONFLICT ("id") DO NOTHING`)
+
+	query, _, err = BuildUpsert("users", rows, []string{"id"}, UseDialect(DialectMySQL))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, "INSERT INTO `users` (`id`, `name`, `email`) VALUES (?, ?, ?), (?, ?, DEFAULT) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)")
+
+	query, _, err = BuildUpsert("users", S{ID: 1}, nil, UseDialect(DialectMySQL))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, "INSERT INTO `users` (`id`) VALUES (?) ON DUPLICATE KEY UPDATE `id` = VALUES(`id`)")
+}
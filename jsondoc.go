@@ -0,0 +1,72 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// JSON[T] stores a value of T type as a JSON document in an SQL column.
+	// Use Var[JSON[T]] to distinguish SQL NULL from a JSON null document
+	JSON[T any] struct {
+		V    T    // the decoded document
+		Null bool // tells if the document is a JSON null
+	}
+)
+
+// NewJSON returns a JSON document that holds the given value
+func NewJSON[T any](v T) JSON[T] {
+	return JSON[T]{V: v}
+}
+
+// Value implements the sql package's driver.Valuer interface.
+// The document is encoded as JSON bytes
+func (j JSON[T]) Value() (driver.Value, error) {
+	if j.Null {
+		return cloneBytes(nullBytes), nil
+	}
+
+	return json.Marshal(j.V)
+}
+
+// Scan implements the sql.Scanner interface.
+// The document is decoded from JSON bytes or string. SQL NULL is only
+// supported by wrapping the document into a Var
+func (j *JSON[T]) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		return fmt.Errorf("converting NULL to %T is unsupported", j)
+	default:
+		return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, j)
+	}
+
+	return j.UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	if j.Null {
+		return nullBytes, nil
+	}
+
+	return json.Marshal(j.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	var def T
+	j.V = def
+	j.Null = bytes.Equal(bytes.TrimSpace(data), nullBytes)
+	if j.Null {
+		return nil
+	}
+
+	return json.Unmarshal(data, &j.V)
+}
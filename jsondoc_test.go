@@ -0,0 +1,72 @@
+package null
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// jsonTestPayload is a document stored in a JSON column
+type jsonTestPayload struct {
+	Name string `json:"name"`
+	Tags []int  `json:"tags"`
+}
+
+func TestJSON(t *testing.T) {
+	var v Var[JSON[jsonTestPayload]]
+
+	// document
+	err := v.Scan([]byte(`{"name":"foo","tags":[1,2]}`))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.State(), StateValue)
+	assertEqualTerminateTest(t, v.Val().Null, false)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", v.Val().V), "{Name:foo Tags:[1 2]}")
+
+	dv, err := v.Value()
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(dv.([]byte)), `{"name":"foo","tags":[1,2]}`)
+
+	// JSON null document
+	err = v.Scan(" null ")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.State(), StateValue)
+	assertEqualTerminateTest(t, v.Val().Null, true)
+
+	dv, err = v.Value()
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(dv.([]byte)), "null")
+
+	// SQL NULL
+	err = v.Scan(nil)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.State(), StateNull)
+
+	dv, err = v.Value()
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, dv == nil, true)
+
+	// errors
+	err = v.Scan([]byte(`{"name":1}`))
+	assertEqualTerminateTest(t, err.Error(), "json: cannot unmarshal number into Go struct field jsonTestPayload.name of type string")
+
+	err = v.Scan(int64(1))
+	assertEqualTerminateTest(t, err.Error(), "unsupported Scan, storing driver.Value type int64 into type *null.JSON[github.com/mauserzjeh/null.jsonTestPayload]")
+
+	var j JSON[jsonTestPayload]
+	err = j.Scan(nil)
+	assertEqualTerminateTest(t, err.Error(), "converting NULL to *null.JSON[github.com/mauserzjeh/null.jsonTestPayload] is unsupported")
+
+	// JSON encoding keeps the document as it is
+	v = From(NewJSON(jsonTestPayload{Name: "bar"}))
+	b, err := json.Marshal(v)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(b), `{"name":"bar","tags":null}`)
+
+	err = json.Unmarshal([]byte(`null`), &v)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.State(), StateNull)
+
+	err = json.Unmarshal([]byte(`{"name":"baz"}`), &v)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.Val().V.Name, "baz")
+}
@@ -0,0 +1,200 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// PatchOp is a single RFC 6902 (JSON Patch) operation
+	PatchOp struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}
+
+	// patchOps is a list of operations where each path can only occur once
+	patchOps struct {
+		ops   []PatchOp
+		index map[string]int
+	}
+
+	jsonPatchOpts struct {
+		filterOpts
+		setOp  string // operation for set values
+		nullOp string // operation for NULL values
+	}
+)
+
+// JSON Patch operations used by CreateJSONPatch
+const (
+	PatchOpAdd     = "add"
+	PatchOpReplace = "replace"
+	PatchOpRemove  = "remove"
+)
+
+var (
+	pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+	defaultJSONPatchOpts = jsonPatchOpts{
+		filterOpts: defaultFilterOpts,
+		setOp:      PatchOpReplace,
+		nullOp:     PatchOpRemove,
+	}
+)
+
+// UseSetOp sets the JSON Patch operation used for set values.
+// It can be either "replace" (default) or "add"
+func UseSetOp(op string) optFunc[jsonPatchOpts] {
+	return func(o *jsonPatchOpts) {
+		o.setOp = op
+	}
+}
+
+// UseNullOp sets the JSON Patch operation used for NULL values.
+// It can be either "remove" (default), "replace" or "add". The latter two
+// set the value to null instead of removing it
+func UseNullOp(op string) optFunc[jsonPatchOpts] {
+	return func(o *jsonPatchOpts) {
+		o.nullOp = op
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The value is omitted for remove operations
+func (p PatchOp) MarshalJSON() ([]byte, error) {
+	if p.Op == PatchOpRemove {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{p.Op, p.Path})
+	}
+
+	type patchOp PatchOp
+	return json.Marshal(patchOp(p))
+}
+
+// CreateJSONPatch creates RFC 6902 (JSON Patch) operations from the given structure.
+// Fields are selected the same way as in FilterStruct. Set values become
+// replace (or add) operations, NULL values become remove (or replace with null)
+// operations and unset values are left out. Filterable structs and
+// map[string]any values are walked recursively.
+func CreateJSONPatch(s any, opts ...option) ([]PatchOp, error) {
+	if s == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	rt := reflect.TypeOf(s)
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. input must be a struct", s)
+	}
+
+	fOpts, err := newOpts(defaultJSONPatchOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if fOpts.setOp != PatchOpReplace && fOpts.setOp != PatchOpAdd {
+		return nil, fmt.Errorf("invalid operation %q for set values", fOpts.setOp)
+	}
+
+	if fOpts.nullOp != PatchOpRemove && fOpts.nullOp != PatchOpReplace && fOpts.nullOp != PatchOpAdd {
+		return nil, fmt.Errorf("invalid operation %q for NULL values", fOpts.nullOp)
+	}
+
+	ops := &patchOps{
+		ops:   []PatchOp{},
+		index: make(map[string]int),
+	}
+	jsonPatchStruct(fOpts, "", reflect.ValueOf(s), ops, true)
+
+	return ops.ops, nil
+}
+
+// jsonPatchStruct collects the operations of the given struct under the given path
+func jsonPatchStruct(opts jsonPatchOpts, path string, val reflect.Value, ops *patchOps, override bool) {
+	for _, f := range structFields(opts.tag, val) {
+		fieldValue := f.value.Interface()
+		fieldPath := path + "/" + pointerEscaper.Replace(f.name)
+
+		switch fv := fieldValue.(type) {
+		case nullVar:
+			if fv.isSet() {
+				ops.put(jsonPatchOp(opts, fieldPath, fv.getVal()), override)
+			}
+
+		case Filterable:
+			if f.value.Kind() != reflect.Struct {
+				continue
+			}
+
+			// if embedded then the fields need to be on the same level as others
+			if f.embedded && f.name == "" {
+				jsonPatchStruct(opts, path, f.value, ops, false)
+				continue
+			}
+			jsonPatchStruct(opts, fieldPath, f.value, ops, override)
+
+		case map[string]any:
+			jsonPatchMap(opts, fieldPath, fv, ops, override)
+
+		default:
+			// structs that don't implement Filterable are only used
+			// if they have a valid tag name
+			if f.name != "" {
+				ops.put(jsonPatchOp(opts, fieldPath, fieldValue), override)
+			}
+		}
+	}
+}
+
+// jsonPatchMap collects the operations of the given map under the given path
+func jsonPatchMap(opts jsonPatchOpts, path string, m map[string]any, ops *patchOps, override bool) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		keyPath := path + "/" + pointerEscaper.Replace(k)
+
+		switch v := m[k].(type) {
+		case nullVar:
+			if v.isSet() {
+				ops.put(jsonPatchOp(opts, keyPath, v.getVal()), override)
+			}
+		case map[string]any:
+			jsonPatchMap(opts, keyPath, v, ops, override)
+		default:
+			ops.put(jsonPatchOp(opts, keyPath, v), override)
+		}
+	}
+}
+
+// jsonPatchOp creates the operation for the given value
+func jsonPatchOp(opts jsonPatchOpts, path string, value any) PatchOp {
+	if value == nil {
+		return PatchOp{Op: opts.nullOp, Path: path}
+	}
+
+	return PatchOp{Op: opts.setOp, Path: path, Value: value}
+}
+
+// put adds the operation. If an operation already exists for the same path
+// it is only replaced if override is true, but it keeps its position
+func (p *patchOps) put(op PatchOp, override bool) {
+	if i, ok := p.index[op.Path]; ok {
+		if override {
+			p.ops[i] = op
+		}
+		return
+	}
+
+	p.index[op.Path] = len(p.ops)
+	p.ops = append(p.ops, op)
+}
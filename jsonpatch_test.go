@@ -0,0 +1,90 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCreateJSONPatch(t *testing.T) {
+	type S2 struct {
+		Filterable
+
+		C Var[int64] `json:"c"`
+		D Var[int64] `json:"d"`
+	}
+
+	type S1 struct {
+		Filterable
+
+		A      Var[string]    `json:"a"`
+		B      Var[string]    `json:"b/b"`
+		Unset  Var[string]    `json:"unset"`
+		Plain  string         `json:"plain" custom_tag:"plain"`
+		Struct S2             `json:"s2"`
+		M      map[string]any `json:"m"`
+		NoTag  Var[string]
+		S2
+	}
+
+	_, err := CreateJSONPatch(nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, err = CreateJSONPatch(int64(1))
+	assertEqualTerminateTest(t, err.Error(), "invalid type int64. input must be a struct")
+
+	_, err = CreateJSONPatch(S1{}, UseSetOp(PatchOpRemove))
+	assertEqualTerminateTest(t, err.Error(), `invalid operation "remove" for set values`)
+
+	_, err = CreateJSONPatch(S1{}, UseNullOp("copy"))
+	assertEqualTerminateTest(t, err.Error(), `invalid operation "copy" for NULL values`)
+
+	s := S1{
+		A:     From("A"),
+		B:     Null[string](),
+		Plain: "plain",
+		Struct: S2{
+			C: From[int64](1),
+		},
+		M: map[string]any{
+			"y": Null[int](),
+			"x": From(1),
+			"z": Unset[int](),
+			"n": map[string]any{
+				"~": "tilde",
+			},
+		},
+		NoTag: From("no tag"),
+		S2: S2{
+			C: From[int64](2),
+			D: Null[int64](),
+		},
+	}
+
+	ops, err := CreateJSONPatch(s)
+	assertEqualTerminateTest(t, err == nil, true)
+
+	j, _ := json.Marshal(ops)
+	assertEqualTerminateTest(t, string(j), `[`+
+		`{"op":"replace","path":"/a","value":"A"},`+
+		`{"op":"remove","path":"/b~1b"},`+
+		`{"op":"replace","path":"/plain","value":"plain"},`+
+		`{"op":"replace","path":"/s2/c","value":1},`+
+		`{"op":"replace","path":"/m/n/~0","value":"tilde"},`+
+		`{"op":"replace","path":"/m/x","value":1},`+
+		`{"op":"remove","path":"/m/y"},`+
+		`{"op":"replace","path":"/c","value":2},`+
+		`{"op":"remove","path":"/d"}`+
+		`]`)
+
+	ops, err = CreateJSONPatch(s, UseTag("custom_tag"), UseSetOp(PatchOpAdd), UseNullOp(PatchOpReplace))
+	assertEqualTerminateTest(t, err == nil, true)
+
+	j, _ = json.Marshal(ops)
+	assertEqualTerminateTest(t, string(j), `[{"op":"add","path":"/plain","value":"plain"}]`)
+
+	ops, err = CreateJSONPatch(S2{D: Null[int64]()}, UseNullOp(PatchOpReplace))
+	assertEqualTerminateTest(t, err == nil, true)
+
+	j, _ = json.Marshal(ops)
+	assertEqualTerminateTest(t, string(j), `[{"op":"replace","path":"/d","value":null}]`)
+}
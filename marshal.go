@@ -0,0 +1,194 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type (
+	// jsonObject is a JSON object that keeps the order of its keys
+	jsonObject struct {
+		keys   []string
+		values map[string][]byte
+	}
+)
+
+// MarshalStruct encodes the given structure as JSON leaving out the unset nullable fields.
+// Fields are selected the same way as in FilterStruct, but unlike FilterStruct
+// the encoded fields keep their declaration order.
+func MarshalStruct(s any, opts ...option) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	rt := reflect.TypeOf(s)
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. input must be a struct", s)
+	}
+
+	// set options
+	fOpts, err := newOpts(defaultFilterOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := marshalStruct(fOpts.tag, reflect.ValueOf(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.bytes()
+}
+
+// marshalStruct encodes the fields of the given struct into a jsonObject
+func marshalStruct(tag string, val reflect.Value) (*jsonObject, error) {
+	obj := newJSONObject()
+
+	for _, f := range structFields(tag, val) {
+		fieldValue := f.value.Interface()
+
+		switch f.field.Type.Kind() {
+		case reflect.Struct:
+			if nv, ok := fieldValue.(nullVar); ok {
+				if !nv.isSet() {
+					continue
+				}
+
+				b, err := marshalValue(tag, nv.getVal())
+				if err != nil {
+					return nil, err
+				}
+				obj.put(f.name, b, true)
+				continue
+			}
+
+			if _, ok := fieldValue.(Filterable); ok {
+				fs, err := marshalStruct(tag, f.value)
+				if err != nil {
+					return nil, err
+				}
+
+				// if embedded then the fields need to be on the same level as others
+				if f.embedded && f.name == "" {
+					for _, k := range fs.keys {
+						obj.put(k, fs.values[k], false)
+					}
+					continue
+				}
+
+				if len(fs.keys) == 0 {
+					continue
+				}
+
+				b, err := fs.bytes()
+				if err != nil {
+					return nil, err
+				}
+				obj.put(f.name, b, true)
+				continue
+			}
+
+			// if it doesn't implement Filterable just use it's value,
+			// but only if it has a valid tag name
+			if f.name == "" {
+				continue
+			}
+
+			b, err := json.Marshal(fieldValue)
+			if err != nil {
+				return nil, err
+			}
+			obj.put(f.name, b, true)
+
+		case reflect.Map:
+			if m, ok := fieldValue.(map[string]any); ok {
+				fm := filterMap(m)
+				if len(fm) == 0 {
+					continue
+				}
+				fieldValue = fm
+			}
+
+			b, err := json.Marshal(fieldValue)
+			if err != nil {
+				return nil, err
+			}
+			obj.put(f.name, b, true)
+
+		default:
+			b, err := json.Marshal(fieldValue)
+			if err != nil {
+				return nil, err
+			}
+			obj.put(f.name, b, true)
+		}
+	}
+
+	return obj, nil
+}
+
+// marshalValue encodes the value of a nullable variable. Filterable structs
+// without their own json.Marshaler implementation are encoded recursively
+func marshalValue(tag string, v any) ([]byte, error) {
+	if _, ok := v.(json.Marshaler); !ok {
+		if _, ok := v.(Filterable); ok {
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Struct {
+				obj, err := marshalStruct(tag, rv)
+				if err != nil {
+					return nil, err
+				}
+				return obj.bytes()
+			}
+		}
+	}
+
+	return json.Marshal(v)
+}
+
+// newJSONObject creates an empty jsonObject
+func newJSONObject() *jsonObject {
+	return &jsonObject{
+		keys:   []string{},
+		values: make(map[string][]byte),
+	}
+}
+
+// put adds the key with the given encoded value. If the key already exists
+// its value is only replaced if override is true, but it keeps its position
+func (o *jsonObject) put(key string, value []byte, override bool) {
+	if _, ok := o.values[key]; ok {
+		if override {
+			o.values[key] = value
+		}
+		return
+	}
+
+	o.keys = append(o.keys, key)
+	o.values[key] = value
+}
+
+// bytes encodes the object
+func (o *jsonObject) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(o.values[k])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
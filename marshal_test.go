@@ -0,0 +1,101 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOmitZero(t *testing.T) {
+	type S struct {
+		A Var[string] `json:"a,omitzero"`
+		B Var[string] `json:"b,omitzero"`
+		C Var[string] `json:"c,omitzero"`
+	}
+
+	s := S{
+		A: From("A"),
+		B: Null[string](),
+	}
+
+	assertEqualTerminateTest(t, Unset[string]().IsZero(), true)
+	assertEqualTerminateTest(t, Null[string]().IsZero(), false)
+	assertEqualTerminateTest(t, From("").IsZero(), false)
+
+	j, err := json.Marshal(s)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(j), `{"a":"A","b":null}`)
+}
+
+func TestMarshalStruct(t *testing.T) {
+	type S3 struct {
+		Filterable
+
+		Z Var[string] `json:"z"`
+		Y Var[string] `json:"y"`
+	}
+
+	type S2 struct {
+		Filterable
+
+		D Var[int64] `json:"d" custom_tag:"dd"`
+		E Var[int64] `json:"e"`
+	}
+
+	type S1 struct {
+		Filterable
+
+		C          Var[string]    `json:"c" custom_tag:"cc"`
+		A          Var[string]    `json:"a"`
+		B          Var[string]    `json:"b" custom_tag:"bb"`
+		Struct     S2             `json:"s2" custom_tag:"s2"`
+		Empty      S2             `json:"empty"`
+		Nested     Var[S2]        `json:"nested"`
+		M          map[string]any `json:"m"`
+		Plain      string         `json:"plain"`
+		Skipped    string         `json:"-"`
+		NoTag      Var[string]
+		unexported Var[string]
+		S3
+	}
+
+	_, err := MarshalStruct(nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, err = MarshalStruct(int64(1))
+	assertEqualTerminateTest(t, err.Error(), "invalid type int64. input must be a struct")
+
+	_, err = MarshalStruct(struct{}{}, UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+
+	j, err := MarshalStruct(S1{})
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(j), `{"plain":""}`)
+
+	s := S1{
+		C: From("C"),
+		B: Null[string](),
+		Struct: S2{
+			D: From[int64](1),
+		},
+		Nested: From(S2{
+			E: Null[int64](),
+		}),
+		M: map[string]any{
+			"set":   From(1),
+			"unset": Unset[int](),
+		},
+		Plain: "plain",
+		NoTag: From("no tag"),
+		S3: S3{
+			Y: From("Y"),
+		},
+	}
+
+	j, err = MarshalStruct(s)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(j), `{"c":"C","b":null,"s2":{"d":1},"nested":{"e":null},"m":{"set":1},"plain":"plain","y":"Y"}`)
+
+	j, err = MarshalStruct(s, UseTag("custom_tag"))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(j), `{"cc":"C","bb":null,"s2":{"dd":1}}`)
+}
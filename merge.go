@@ -0,0 +1,172 @@
+package null
+
+import (
+	"reflect"
+)
+
+// Merge merges the given layers into a single value, where later layers take precedence.
+// Nullable fields are overridden by the later layers only where they are set, so a
+// NULL set explicitly wins over a previous value, but an unset field doesn't.
+// The top level struct, Filterable structs, structs with nullable fields and map[string]any
+// values are merged recursively, while other fields are overridden by the later layers
+// where they are not zero values.
+func Merge[T any](layers ...T) T {
+	r, _ := merge(layers, false)
+	return r
+}
+
+// MergeWithSources merges the given layers the same way as Merge and returns the
+// index of the layer each final value came from as well. The keys are the paths of
+// the values made of the field names and map keys joined with dots, e.g. DB.Host.
+// Fields of embedded structs are promoted, so their paths don't contain the embedded struct.
+func MergeWithSources[T any](layers ...T) (T, map[string]int) {
+	return merge(layers, true)
+}
+
+// merge merges the given layers and collects the sources if needed
+func merge[T any](layers []T, withSources bool) (T, map[string]int) {
+	var r T
+
+	var sources map[string]int
+	if withSources {
+		sources = make(map[string]int)
+	}
+
+	rv := reflect.ValueOf(&r).Elem()
+	for i := range layers {
+		mergeLayer(rv, reflect.ValueOf(&layers[i]).Elem(), i, "", sources, true)
+	}
+
+	return r, sources
+}
+
+// mergeLayer merges src of the given layer into dst.
+// top is true for the top level value
+func mergeLayer(dst, src reflect.Value, layer int, path string, sources map[string]int, top bool) {
+	switch v := src.Interface().(type) {
+	case nullVar:
+		if v.isSet() {
+			dst.Set(src)
+			setSource(sources, path, layer)
+		}
+		return
+	case map[string]any:
+		if v == nil {
+			return
+		}
+
+		m, _ := dst.Interface().(map[string]any)
+		dst.Set(reflect.ValueOf(mergeLayerMap(m, v, layer, path, sources)))
+		return
+	}
+
+	if walkStruct(src, top) {
+		for i := 0; i < src.NumField(); i++ {
+			if !dst.Field(i).CanSet() {
+				continue
+			}
+
+			field := src.Type().Field(i)
+			fieldPath := path
+			if !field.Anonymous {
+				fieldPath = joinPath(path, field.Name)
+			}
+			mergeLayer(dst.Field(i), src.Field(i), layer, fieldPath, sources, false)
+		}
+		return
+	}
+
+	if !src.IsZero() {
+		dst.Set(src)
+		setSource(sources, path, layer)
+	}
+}
+
+// mergeLayerMap merges src of the given layer into dst.
+// dst is created if it is nil, while src is never modified
+func mergeLayerMap(dst, src map[string]any, layer int, path string, sources map[string]int) map[string]any {
+	if dst == nil {
+		dst = make(map[string]any, len(src))
+	}
+
+	for k, v := range src {
+		keyPath := joinPath(path, k)
+
+		switch val := v.(type) {
+		case nullVar:
+			if val.isSet() {
+				dst[k] = v
+				setSource(sources, keyPath, layer)
+			}
+		case map[string]any:
+			m, _ := dst[k].(map[string]any)
+			dst[k] = mergeLayerMap(m, val, layer, keyPath, sources)
+		default:
+			dst[k] = v
+			setSource(sources, keyPath, layer)
+		}
+	}
+
+	return dst
+}
+
+// walkStruct returns if the fields of the given value have to be handled one by one,
+// instead of using it as a single value. It is true for Filterable structs, structs with
+// nullable fields and the top level struct, unless it has no exported fields, e.g. time.Time
+func walkStruct(v reflect.Value, top bool) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	if _, ok := v.Interface().(Filterable); ok {
+		return true
+	}
+
+	if top {
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				return true
+			}
+		}
+		return false
+	}
+
+	return hasNullVars(v.Type())
+}
+
+// hasNullVars returns if the given struct type has nullable fields, including
+// the fields of its nested structs
+func hasNullVars(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Type.Implements(nullVarType) {
+			return true
+		}
+
+		if field.Type.Kind() == reflect.Struct && hasNullVars(field.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setSource records the layer of the value at the given path
+func setSource(sources map[string]int, path string, layer int) {
+	if sources != nil {
+		sources[path] = layer
+	}
+}
+
+// joinPath joins the elements of a value path with a dot
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,122 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	type DB struct {
+		Filterable
+
+		Host Var[string]
+		Port Var[int]
+	}
+
+	type Common struct {
+		Filterable
+
+		Debug Var[bool]
+	}
+
+	type Config struct {
+		Filterable
+		Common
+
+		Name    Var[string]
+		Level   string
+		DB      DB
+		Extra   map[string]any
+		private Var[string]
+	}
+
+	file := Config{
+		Name:  From("file"),
+		Level: "info",
+		DB: DB{
+			Host: From("localhost"),
+			Port: From(5432),
+		},
+		Extra: map[string]any{
+			"a": From(1),
+			"b": map[string]any{
+				"c": "c",
+			},
+		},
+		private: From("private"),
+	}
+
+	env := Config{
+		Common: Common{Debug: From(true)},
+		DB: DB{
+			Port: Null[int](),
+		},
+		Extra: map[string]any{
+			"a": Unset[int](),
+			"b": map[string]any{
+				"d": From("d"),
+			},
+		},
+	}
+
+	flags := Config{
+		Name:  From("flags"),
+		Level: "",
+	}
+
+	got := Merge(file, env, flags)
+	assertEqualTerminateTest(t, got.Name, From("flags"))
+	assertEqualTerminateTest(t, got.Level, "info")
+	assertEqualTerminateTest(t, got.Debug, From(true))
+	assertEqualTerminateTest(t, got.DB.Host, From("localhost"))
+	assertEqualTerminateTest(t, got.DB.Port, Null[int]())
+	assertEqualTerminateTest(t, got.private, Unset[string]())
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", got.Extra), "map[a:{state:2 value:1} b:map[c:c d:{state:2 value:d}]]")
+
+	// the layers are not modified
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", file.Extra), "map[a:{state:2 value:1} b:map[c:c]]")
+
+	got, sources := MergeWithSources(file, env, flags)
+	assertEqualTerminateTest(t, got.Name, From("flags"))
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", sources), "map[DB.Host:0 DB.Port:1 Debug:1 Extra.a:0 Extra.b.c:0 Extra.b.d:1 Level:0 Name:2]")
+
+	assertEqualTerminateTest(t, Merge[Config]().Name, Unset[string]())
+
+	// values other than structs
+	assertEqualTerminateTest(t, Merge(From(1), Unset[int]()), From(1))
+	assertEqualTerminateTest(t, Merge(From(1), Null[int]()), Null[int]())
+	assertEqualTerminateTest(t, Merge(1, 0, 2), 2)
+
+	m := Merge(map[string]any{"a": 1}, map[string]any{"b": 2}, nil)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", m), "map[a:1 b:2]")
+
+	// structs that don't implement Filterable
+	type Limits struct {
+		Max Var[int]
+		Min Var[int]
+	}
+
+	type Plain struct {
+		Host    Var[string]
+		Port    Var[int]
+		Level   string
+		Limits  Limits
+		Started time.Time
+	}
+
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	plain, plainSources := MergeWithSources(
+		Plain{Host: From("a"), Level: "info", Limits: Limits{Max: From(10)}},
+		Plain{Port: From(1), Limits: Limits{Min: From(1)}, Started: ts},
+	)
+	assertEqualTerminateTest(t, plain.Host, From("a"))
+	assertEqualTerminateTest(t, plain.Port, From(1))
+	assertEqualTerminateTest(t, plain.Level, "info")
+	assertEqualTerminateTest(t, plain.Limits, Limits{Max: From(10), Min: From(1)})
+	assertEqualTerminateTest(t, plain.Started, ts)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", plainSources), "map[Host:0 Level:0 Limits.Max:0 Limits.Min:1 Port:1 Started:1]")
+
+	// structs without exported fields are single values
+	assertEqualTerminateTest(t, Merge(ts, time.Time{}), ts)
+}
@@ -0,0 +1,323 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyMergePatch applies the given patch onto target according to RFC 7386 (JSON Merge Patch).
+// target must be a pointer to a struct. patch can be a struct of nullable
+// fields, a pointer to one, or a map[string]any document. Fields are matched
+// by their tags, which can be changed with the UseTag option.
+//
+// Unset fields are left untouched, NULL values clear the target field and
+// set values are assigned to it. Filterable structs and maps are merged recursively.
+func ApplyMergePatch(target any, patch any, opts ...option) error {
+	if target == nil {
+		return errors.New("target cannot be nil")
+	}
+
+	if patch == nil {
+		return errors.New("patch cannot be nil")
+	}
+
+	tv := reflect.ValueOf(target)
+	if tv.Kind() != reflect.Pointer || tv.IsNil() || tv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("invalid type %T. target must be a non-nil pointer to a struct", target)
+	}
+
+	// set options
+	fOpts, err := newOpts(defaultFilterOpts, opts)
+	if err != nil {
+		return err
+	}
+
+	if m, ok := patch.(map[string]any); ok {
+		return applyMergePatchMap(fOpts.tag, tv.Elem(), m)
+	}
+
+	pv := reflect.Indirect(reflect.ValueOf(patch))
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("invalid type %T. patch must be a struct or map[string]any", patch)
+	}
+
+	return applyMergePatchStruct(fOpts.tag, tv.Elem(), pv)
+}
+
+// CreateMergePatch creates an RFC 7386 (JSON Merge Patch) document that
+// turns old into new. Both must be structs of the same type. Fields are
+// matched by their tags, which can be changed with the UseTag option.
+//
+// Changed fields hold their new value, while fields that became NULL or
+// unset hold nil. Filterable structs and maps are compared recursively.
+func CreateMergePatch(old, new any, opts ...option) (map[string]any, error) {
+	if old == nil || new == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	if nv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. input must be a struct", new)
+	}
+
+	if ov.Type() != nv.Type() {
+		return nil, fmt.Errorf("type mismatch %T != %T", old, new)
+	}
+
+	// set options
+	fOpts, err := newOpts(defaultFilterOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return createMergePatch(fOpts.tag, ov, nv), nil
+}
+
+// applyMergePatchStruct applies the fields of the patch struct onto dst
+func applyMergePatchStruct(tag string, dst, patch reflect.Value) error {
+	dstFields := fieldsByName(tag, dst)
+
+	for _, f := range structFields(tag, patch) {
+		fieldValue := f.value.Interface()
+
+		// promoted fields are applied on the same level
+		if f.embedded && f.name == "" {
+			if _, ok := fieldValue.(Filterable); ok {
+				if err := applyMergePatchStruct(tag, dst, f.value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		d, ok := dstFields[f.name]
+		if !ok {
+			continue
+		}
+
+		if nv, ok := fieldValue.(nullVar); ok {
+			if !nv.isSet() {
+				continue
+			}
+			fieldValue = nv.getVal()
+		}
+
+		if err := mergeValue(tag, d, fieldValue); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMergePatchMap applies the keys of the patch document onto dst
+func applyMergePatchMap(tag string, dst reflect.Value, patch map[string]any) error {
+	dstFields := fieldsByName(tag, dst)
+
+	for k, v := range patch {
+		d, ok := dstFields[k]
+		if !ok {
+			continue
+		}
+
+		if nv, ok := v.(nullVar); ok {
+			if !nv.isSet() {
+				continue
+			}
+			v = nv.getVal()
+		}
+
+		if err := mergeValue(tag, d, v); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+// mergeValue merges src into dst. Objects are merged recursively into
+// structs and map[string]any destinations, everything else is assigned
+func mergeValue(tag string, dst reflect.Value, src any) error {
+	_, dstIsVar := dst.Addr().Interface().(nullVarPtr)
+
+	switch s := src.(type) {
+	case nil:
+		return assignValue(dst, nil)
+	case map[string]any:
+		if dst.Kind() == reflect.Struct && !dstIsVar {
+			return applyMergePatchMap(tag, dst, s)
+		}
+
+		if m, ok := dst.Interface().(map[string]any); ok {
+			dst.Set(reflect.ValueOf(mergeMaps(m, s)))
+			return nil
+		}
+
+		// other values are replaced by the patch applied on an empty object,
+		// so that its null members are removed
+		return assignValue(dst, mergeMaps(nil, s))
+	case Filterable:
+		sv := reflect.ValueOf(s)
+		if sv.Kind() == reflect.Struct && dst.Kind() == reflect.Struct && !dstIsVar {
+			return applyMergePatchStruct(tag, dst, sv)
+		}
+	}
+
+	return assignValue(dst, src)
+}
+
+// mergeMaps merges patch into target as described in RFC 7386
+func mergeMaps(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any)
+	}
+
+	for k, v := range patch {
+		if nv, ok := v.(nullVar); ok {
+			if !nv.isSet() {
+				continue
+			}
+			v = nv.getVal()
+		}
+
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+
+		if pm, ok := v.(map[string]any); ok {
+			tm, _ := target[k].(map[string]any)
+			target[k] = mergeMaps(tm, pm)
+			continue
+		}
+
+		target[k] = v
+	}
+
+	return target
+}
+
+// createMergePatch creates a merge patch document from the differences of two structs
+func createMergePatch(tag string, old, new reflect.Value) map[string]any {
+	patch := make(map[string]any)
+
+	for _, f := range structFields(tag, new) {
+		o := old.FieldByIndex(f.field.Index)
+		n := f.value
+
+		switch nVal := n.Interface().(type) {
+		case nullVar:
+			if oVal, ok := o.Interface().(nullVar); ok {
+				if reflect.DeepEqual(oVal, nVal) {
+					continue
+				}
+			} else if reflect.DeepEqual(nilIfEmpty(o), nVal.getVal()) {
+				// the old value of an interface field is not nullable, e.g. nil
+				continue
+			}
+			patch[f.name] = nVal.getVal()
+
+		case Filterable:
+			if n.Kind() != reflect.Struct {
+				continue
+			}
+			fp := createMergePatch(tag, o, n)
+
+			// if embedded then the fields need to be on the same level as others
+			if f.embedded && f.name == "" {
+				for k, v := range fp {
+					if _, ok := patch[k]; !ok {
+						patch[k] = v
+					}
+				}
+				continue
+			}
+
+			if len(fp) > 0 {
+				patch[f.name] = fp
+			}
+
+		case map[string]any:
+			// the old value of an interface field might not be a map,
+			// which is replaced even by an empty one
+			oVal, isMap := o.Interface().(map[string]any)
+			mp := createMapMergePatch(oVal, nVal)
+			if len(mp) > 0 || (!isMap && nVal != nil) {
+				patch[f.name] = mp
+			}
+
+		default:
+			if f.name == "" || reflect.DeepEqual(o.Interface(), nVal) {
+				continue
+			}
+			patch[f.name] = nilIfEmpty(n)
+		}
+	}
+
+	return patch
+}
+
+// createMapMergePatch creates a merge patch document from the differences of two maps
+func createMapMergePatch(old, new map[string]any) map[string]any {
+	patch := make(map[string]any)
+
+	for k, o := range old {
+		if nv, ok := o.(nullVar); ok && nv.getVal() == nil {
+			continue
+		}
+
+		if n, ok := new[k]; !ok || n == nil {
+			patch[k] = nil
+		} else if nv, ok := n.(nullVar); ok && nv.getVal() == nil {
+			patch[k] = nil
+		}
+	}
+
+	for k, n := range new {
+		if nv, ok := n.(nullVar); ok {
+			if nv.getVal() == nil {
+				continue
+			}
+			n = nv.getVal()
+		}
+
+		if n == nil {
+			continue
+		}
+
+		o := old[k]
+		if nv, ok := o.(nullVar); ok {
+			o = nv.getVal()
+		}
+
+		om, oOk := o.(map[string]any)
+		nm, nOk := n.(map[string]any)
+		if oOk && nOk {
+			mp := createMapMergePatch(om, nm)
+			if len(mp) > 0 {
+				patch[k] = mp
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(o, n) {
+			patch[k] = n
+		}
+	}
+
+	return patch
+}
+
+// nilIfEmpty returns nil for nil pointers, maps, slices and interfaces,
+// otherwise it returns the value as an interface
+func nilIfEmpty(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	return v.Interface()
+}
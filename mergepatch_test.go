@@ -0,0 +1,216 @@
+package null
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type mergePatchAddress struct {
+	Filterable
+
+	City   string  `json:"city"`
+	Street *string `json:"street"`
+}
+
+type mergePatchPerson struct {
+	Filterable
+
+	Name    string            `json:"name"`
+	Age     int64             `json:"age"`
+	Email   Var[string]       `json:"email"`
+	Phone   *string           `json:"phone"`
+	Address mergePatchAddress `json:"address"`
+	Extra   map[string]any    `json:"extra"`
+}
+
+type mergePatchAddressPatch struct {
+	Filterable
+
+	City   Var[string] `json:"city"`
+	Street Var[string] `json:"street"`
+}
+
+type mergePatchPersonPatch struct {
+	Filterable
+
+	Name    Var[string]            `json:"name"`
+	Age     Var[int32]             `json:"age"`
+	Email   Var[string]            `json:"email"`
+	Phone   Var[string]            `json:"phone"`
+	Address mergePatchAddressPatch `json:"address"`
+	Extra   map[string]any         `json:"extra"`
+	Unknown Var[string]            `json:"unknown"`
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	street := "Main street"
+	phone := "555-1234"
+	newPerson := func() mergePatchPerson {
+		return mergePatchPerson{
+			Name:  "Peter",
+			Age:   25,
+			Email: From("peter@example.com"),
+			Phone: &phone,
+			Address: mergePatchAddress{
+				City:   "Budapest",
+				Street: &street,
+			},
+			Extra: map[string]any{
+				"a": "A",
+				"b": map[string]any{
+					"c": "C",
+				},
+			},
+		}
+	}
+
+	err := ApplyMergePatch(nil, map[string]any{})
+	assertEqualTerminateTest(t, err.Error(), "target cannot be nil")
+
+	p := newPerson()
+	err = ApplyMergePatch(&p, nil)
+	assertEqualTerminateTest(t, err.Error(), "patch cannot be nil")
+
+	err = ApplyMergePatch(p, map[string]any{})
+	assertEqualTerminateTest(t, err.Error(), "invalid type null.mergePatchPerson. target must be a non-nil pointer to a struct")
+
+	err = ApplyMergePatch(&p, 1)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. patch must be a struct or map[string]any")
+
+	// struct patch
+	var patch mergePatchPersonPatch
+	err = json.Unmarshal([]byte(`{"age":26,"email":null,"phone":"555-0000","address":{"street":null},"extra":{"a":null,"b":{"d":"D"}},"unknown":"?"}`), &patch)
+	assertEqualTerminateTest(t, err == nil, true)
+
+	err = ApplyMergePatch(&p, patch)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, p.Name, "Peter")
+	assertEqualTerminateTest(t, p.Age, int64(26))
+	assertEqualTerminateTest(t, p.Email.State(), StateNull)
+	assertEqualTerminateTest(t, *p.Phone, "555-0000")
+	assertEqualTerminateTest(t, p.Address.City, "Budapest")
+	assertEqualTerminateTest(t, p.Address.Street == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", p.Extra), "map[b:map[c:C d:D]]")
+
+	// document patch
+	p = newPerson()
+	var doc map[string]any
+	err = json.Unmarshal([]byte(`{"name":"Anna","age":20,"phone":null,"address":{"city":"Szeged"},"extra":null}`), &doc)
+	assertEqualTerminateTest(t, err == nil, true)
+
+	err = ApplyMergePatch(&p, doc)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, p.Name, "Anna")
+	assertEqualTerminateTest(t, p.Age, int64(20))
+	assertEqualTerminateTest(t, p.Email.Val(), "peter@example.com")
+	assertEqualTerminateTest(t, p.Phone == nil, true)
+	assertEqualTerminateTest(t, p.Address.City, "Szeged")
+	assertEqualTerminateTest(t, *p.Address.Street, street)
+	assertEqualTerminateTest(t, p.Extra == nil, true)
+
+	// invalid conversion
+	p = newPerson()
+	err = ApplyMergePatch(&p, map[string]any{"age": "foo"})
+	assertEqualTerminateTest(t, err.Error(), `age: assigning string to int64: converting driver.Value type string ("foo") to a int64: invalid syntax`)
+
+	err = ApplyMergePatch(&p, map[string]any{}, UseSetNull())
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+
+	// objects replace the values of interface fields without their null members
+	type anyTarget struct {
+		X any `json:"x"`
+	}
+
+	var a anyTarget
+	err = ApplyMergePatch(&a, map[string]any{"x": map[string]any{"a": nil, "b": map[string]any{"c": nil}}})
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", a.X), "map[b:map[]]")
+
+	a.X = 1
+	err = ApplyMergePatch(&a, map[string]any{"x": map[string]any{"a": "A"}})
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", a.X), "map[a:A]")
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	street := "Main street"
+	old := mergePatchPerson{
+		Name:  "Peter",
+		Age:   25,
+		Email: From("peter@example.com"),
+		Address: mergePatchAddress{
+			City:   "Budapest",
+			Street: &street,
+		},
+		Extra: map[string]any{
+			"a": "A",
+			"b": map[string]any{
+				"c": "C",
+			},
+		},
+	}
+
+	_, err := CreateMergePatch(nil, old)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, err = CreateMergePatch(1, 2)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. input must be a struct")
+
+	_, err = CreateMergePatch(old, mergePatchAddress{})
+	assertEqualTerminateTest(t, err.Error(), "type mismatch null.mergePatchPerson != null.mergePatchAddress")
+
+	_, err = CreateMergePatch(old, old, UseTag("db"), UseChangeReport(nil))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+
+	patch, err := CreateMergePatch(old, old)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, len(patch), 0)
+
+	new := old
+	new.Age = 26
+	new.Email = Null[string]()
+	new.Address.Street = nil
+	new.Extra = map[string]any{
+		"b": map[string]any{
+			"c": "C",
+			"d": From("D"),
+		},
+	}
+
+	patch, err = CreateMergePatch(old, new)
+	assertEqualTerminateTest(t, err == nil, true)
+
+	j, _ := json.Marshal(patch)
+	assertEqualTerminateTest(t, string(j), `{"address":{"street":null},"age":26,"email":null,"extra":{"a":null,"b":{"d":"D"}}}`)
+
+	// applying the created patch results in the new value
+	err = ApplyMergePatch(&old, patch)
+	assertEqualTerminateTest(t, err == nil, true)
+	oj, _ := json.Marshal(old)
+	nj, _ := json.Marshal(new)
+	assertEqualTerminateTest(t, string(oj), string(nj))
+
+	// interface fields holding different types
+	type anyField struct {
+		X any `json:"x"`
+	}
+
+	for _, tc := range []struct {
+		old, new anyField
+		want     string
+	}{
+		{anyField{}, anyField{X: From(1)}, `{"x":1}`},
+		{anyField{}, anyField{X: Unset[int]()}, `{}`},
+		{anyField{X: 1}, anyField{X: From(1)}, `{}`},
+		{anyField{X: "a"}, anyField{X: Null[int]()}, `{"x":null}`},
+		{anyField{}, anyField{X: map[string]any{"a": 1}}, `{"x":{"a":1}}`},
+		{anyField{X: 1}, anyField{X: map[string]any{"a": nil}}, `{"x":{}}`},
+		{anyField{X: map[string]any{"a": 1}}, anyField{X: map[string]any{"a": 1}}, `{}`},
+	} {
+		patch, err = CreateMergePatch(tc.old, tc.new)
+		assertEqualTerminateTest(t, err == nil, true)
+		j, _ = json.Marshal(patch)
+		assertEqualTerminateTest(t, string(j), tc.want)
+	}
+}
@@ -2,22 +2,34 @@ package null
 
 import (
 	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"reflect"
 )
 
 type (
 	// Var[T] defines a variable with generic field of T type
 	Var[T any] struct {
-		set   bool // tells if the value was set
-		valid bool // tells if the value is NULL
-		value T    // the value itself
+		state State // tells if the value was set and if it is NULL
+		value T     // the value itself
 	}
 
+	// State defines the state of a Var
+	State uint8
+
 	// an internal interface that helps recognizing any nullable variables
 	nullVar interface {
 		isSet() bool
 		getVal() any
+		valueType() reflect.Type
+	}
+
+	// an internal interface that helps assigning values to any nullable variables
+	nullVarPtr interface {
+		SetNil()
+		Unset()
+		assign(src any) error
 	}
 
 	// an exported interface that helps recognizing which structs can be filtered
@@ -27,30 +39,87 @@ type (
 	}
 )
 
+const (
+	StateUnset State = iota // the value was not set
+	StateNull               // the value was set to NULL
+	StateValue              // the value was set to a non-NULL value
+)
+
 var (
 	nullBytes = []byte("null")
 )
 
+// String implements the fmt.Stringer interface
+func (s State) String() string {
+	switch s {
+	case StateUnset:
+		return "unset"
+	case StateNull:
+		return "null"
+	case StateValue:
+		return "value"
+	default:
+		return "invalid"
+	}
+}
+
+// From returns a Var that holds the given value
+func From[T any](value T) Var[T] {
+	return Var[T]{
+		state: StateValue,
+		value: value,
+	}
+}
+
+// Null returns a Var that is set to NULL
+func Null[T any]() Var[T] {
+	return Var[T]{
+		state: StateNull,
+	}
+}
+
+// Unset returns a Var that is not set
+func Unset[T any]() Var[T] {
+	return Var[T]{}
+}
+
+// FromPtr returns a Var that holds the value the given pointer points to.
+// A nil pointer results in a NULL value
+func FromPtr[T any](ptr *T) Var[T] {
+	if ptr == nil {
+		return Null[T]()
+	}
+
+	return From(*ptr)
+}
+
+// FromSQLNull returns a Var from the given sql.Null.
+// An invalid sql.Null results in a NULL value
+func FromSQLNull[T any](n sql.Null[T]) Var[T] {
+	if !n.Valid {
+		return Null[T]()
+	}
+
+	return From(n.V)
+}
+
 // Set sets the value
 func (v *Var[T]) Set(value T) {
-	v.set = true
-	v.valid = true
+	v.state = StateValue
 	v.value = value
 }
 
 // Unset unsets the value
 func (v *Var[T]) Unset() {
 	var def T
-	v.set = false
-	v.valid = false
+	v.state = StateUnset
 	v.value = def
 }
 
 // SetNil sets the value to NULL
 func (v *Var[T]) SetNil() {
 	var def T
-	v.set = true
-	v.valid = false
+	v.state = StateNull
 	v.value = def
 }
 
@@ -61,17 +130,71 @@ func (v Var[T]) Val() T {
 
 // IsSet returns if the value was set
 func (v Var[T]) IsSet() bool {
-	return v.set
+	return v.state != StateUnset
 }
 
 // Valid returns if the value is NULL
 func (v Var[T]) Valid() bool {
-	return v.valid
+	return v.state == StateValue
+}
+
+// IsZero returns if the value was not set.
+// It makes the omitzero option of the encoding/json package skip unset values
+func (v Var[T]) IsZero() bool {
+	return v.state == StateUnset
+}
+
+// State returns the state of the value
+func (v Var[T]) State() State {
+	return v.state
+}
+
+// Match calls the function that belongs to the current state of the value.
+// Nil functions are skipped
+func (v Var[T]) Match(onUnset, onNull func(), onValue func(T)) {
+	switch v.state {
+	case StateUnset:
+		if onUnset != nil {
+			onUnset()
+		}
+	case StateNull:
+		if onNull != nil {
+			onNull()
+		}
+	case StateValue:
+		if onValue != nil {
+			onValue(v.value)
+		}
+	}
+}
+
+// Ptr returns a pointer to a copy of the value.
+// It returns nil if the value is unset or NULL
+func (v Var[T]) Ptr() *T {
+	if v.state != StateValue {
+		return nil
+	}
+
+	value := v.value
+	return &value
+}
+
+// ToSQLNull returns the value as an sql.Null.
+// Both unset and NULL values result in an invalid sql.Null
+func (v Var[T]) ToSQLNull() sql.Null[T] {
+	if v.state != StateValue {
+		return sql.Null[T]{}
+	}
+
+	return sql.Null[T]{
+		V:     v.value,
+		Valid: true,
+	}
 }
 
 // MarshalJSON implements the json.Marshaler interface
 func (v Var[T]) MarshalJSON() ([]byte, error) {
-	if !v.valid || !v.set {
+	if v.state != StateValue {
 		return nullBytes, nil
 	}
 
@@ -81,21 +204,20 @@ func (v Var[T]) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements the json.Unmarshaler interface
 func (v *Var[T]) UnmarshalJSON(data []byte) error {
 	var def T
-	v.set = true
 	v.value = def
 
 	if bytes.Equal(nullBytes, data) {
-		v.valid = false
+		v.state = StateNull
 		return nil
 	}
 
-	v.valid = true
+	v.state = StateValue
 	return json.Unmarshal(data, &v.value)
 }
 
 // Value implements the sql package's driver.Valuer interface
 func (v Var[T]) Value() (driver.Value, error) {
-	if !v.set || !v.valid {
+	if v.state != StateValue {
 		return nil, nil
 	}
 
@@ -103,6 +225,11 @@ func (v Var[T]) Value() (driver.Value, error) {
 	case driver.Valuer:
 		return val.Value()
 	default:
+		// slices are not valid driver values, so they are encoded as array literals
+		rv := reflect.ValueOf(val)
+		if rv.IsValid() && isPGArrayType(rv.Type()) {
+			return encodePGArray(rv), nil
+		}
 		return v.value, nil
 	}
 }
@@ -110,27 +237,59 @@ func (v Var[T]) Value() (driver.Value, error) {
 // Scan implements the sql.Scanner interface
 func (v *Var[T]) Scan(src any) error {
 	var def T
-	v.set = true
 
 	if src == nil {
-		v.valid, v.value = false, def
+		v.state, v.value = StateNull, def
 		return nil
 	}
 
-	v.valid = true
+	v.state = StateValue
 	return convertAssign(&v.value, src)
 }
 
+// scanWith implements the scopedScanner interface for internal usage
+func (v *Var[T]) scanWith(src any, scoped []Converter) error {
+	var def T
+
+	if src == nil {
+		v.state, v.value = StateNull, def
+		return nil
+	}
+
+	v.state = StateValue
+	return convertAssignWith(&v.value, src, scoped)
+}
+
 // isSet implements the nullVar interface for internal usage
 func (v Var[T]) isSet() bool {
-	return v.set
+	return v.state != StateUnset
+}
+
+// valueType implements the nullVar interface for internal usage
+func (v Var[T]) valueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
 }
 
 // getVal implements the nullVar interface for internal usage
 func (v Var[T]) getVal() any {
-	if !v.set || !v.valid {
+	if v.state != StateValue {
 		return nil
 	}
 
 	return v.value
 }
+
+// assign implements the nullVarPtr interface for internal usage.
+// A nil src sets the value to NULL, otherwise src is converted to T
+func (v *Var[T]) assign(src any) error {
+	switch val := src.(type) {
+	case nil:
+		v.SetNil()
+		return nil
+	case T:
+		v.Set(val)
+		return nil
+	}
+
+	return v.Scan(src)
+}
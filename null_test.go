@@ -2,13 +2,16 @@ package null
 
 import (
 	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
+	"unsafe"
 )
 
 // Comparable interface for custom types for testing
@@ -756,3 +759,147 @@ func TestNullVar(t *testing.T) {
 	}
 
 }
+
+// TestConstructors tests the Var constructor functions
+func TestConstructors(t *testing.T) {
+	if err := checkVar(t, From("foo"), true, true, "foo"); err != nil {
+		t.Errorf("[From] %v", err)
+	}
+
+	if err := checkVar(t, Null[string](), true, false, ""); err != nil {
+		t.Errorf("[Null] %v", err)
+	}
+
+	if err := checkVar(t, Unset[string](), false, false, ""); err != nil {
+		t.Errorf("[Unset] %v", err)
+	}
+
+	if err := checkVar(t, FromPtr[string](nil), true, false, ""); err != nil {
+		t.Errorf("[FromPtr - nil] %v", err)
+	}
+
+	s := "foo"
+	if err := checkVar(t, FromPtr(&s), true, true, "foo"); err != nil {
+		t.Errorf("[FromPtr - value] %v", err)
+	}
+
+	if err := checkVar(t, FromSQLNull(sql.Null[string]{}), true, false, ""); err != nil {
+		t.Errorf("[FromSQLNull - nil] %v", err)
+	}
+
+	if err := checkVar(t, FromSQLNull(sql.Null[string]{V: "foo", Valid: true}), true, true, "foo"); err != nil {
+		t.Errorf("[FromSQLNull - value] %v", err)
+	}
+}
+
+// TestPtr tests the Ptr and ToSQLNull conversions
+func TestPtr(t *testing.T) {
+	assertEqualTerminateTest(t, Unset[int]().Ptr() == nil, true)
+	assertEqualTerminateTest(t, Null[int]().Ptr() == nil, true)
+
+	v := From(42)
+	p := v.Ptr()
+	assertEqualTerminateTest(t, *p, 42)
+
+	// the pointer must not alias the value inside Var
+	*p = 1
+	assertEqualTerminateTest(t, v.Val(), 42)
+
+	assertEqualTerminateTest(t, Unset[int]().ToSQLNull(), sql.Null[int]{})
+	assertEqualTerminateTest(t, Null[int]().ToSQLNull(), sql.Null[int]{})
+	assertEqualTerminateTest(t, v.ToSQLNull(), sql.Null[int]{V: 42, Valid: true})
+}
+
+// TestState tests the State and Match methods
+func TestState(t *testing.T) {
+	assertEqualTerminateTest(t, Unset[int]().State(), StateUnset)
+	assertEqualTerminateTest(t, Null[int]().State(), StateNull)
+	assertEqualTerminateTest(t, From(1).State(), StateValue)
+
+	assertEqualTerminateTest(t, StateUnset.String(), "unset")
+	assertEqualTerminateTest(t, StateNull.String(), "null")
+	assertEqualTerminateTest(t, StateValue.String(), "value")
+	assertEqualTerminateTest(t, State(42).String(), "invalid")
+
+	match := func(v Var[int]) string {
+		got := ""
+		v.Match(
+			func() { got = "unset" },
+			func() { got = "null" },
+			func(i int) { got = fmt.Sprintf("value %d", i) },
+		)
+		return got
+	}
+
+	assertEqualTerminateTest(t, match(Unset[int]()), "unset")
+	assertEqualTerminateTest(t, match(Null[int]()), "null")
+	assertEqualTerminateTest(t, match(From(42)), "value 42")
+
+	// nil functions are skipped
+	From(42).Match(nil, nil, nil)
+
+	// the state is packed into a single byte
+	assertEqualTerminateTest(t, unsafe.Sizeof(Var[bool]{}), uintptr(2))
+}
+
+// TestXML tests the xml marshalling and unmarshalling of Var
+func TestXML(t *testing.T) {
+	type Item struct {
+		XMLName xml.Name    `xml:"item"`
+		ID      Var[int64]  `xml:"id,attr"`
+		Code    Var[string] `xml:"code,attr"`
+		Name    Var[string] `xml:"name"`
+		Age     Var[int64]  `xml:"age"`
+		Note    Var[string] `xml:"note"`
+	}
+
+	item := Item{
+		ID:   From[int64](1),
+		Name: From("foo & bar"),
+		Age:  Null[int64](),
+	}
+
+	x, err := xml.Marshal(item)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(x), `<item id="1"><name>foo &amp; bar</name><age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age></item>`)
+
+	var got Item
+	err = xml.Unmarshal(x, &got)
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, got.ID, true, true, 1); err != nil {
+		t.Errorf("[id] %v", err)
+	}
+	if err := checkVar(t, got.Code, false, false, ""); err != nil {
+		t.Errorf("[code] %v", err)
+	}
+	if err := checkVar(t, got.Name, true, true, "foo & bar"); err != nil {
+		t.Errorf("[name] %v", err)
+	}
+	if err := checkVar(t, got.Age, true, false, 0); err != nil {
+		t.Errorf("[age] %v", err)
+	}
+	if err := checkVar(t, got.Note, false, false, ""); err != nil {
+		t.Errorf("[note] %v", err)
+	}
+
+	// undeclared prefix and NULL attribute
+	got = Item{}
+	err = xml.Unmarshal([]byte(`<item code=""><note xsi:nil="1">ignored</note><age>25</age></item>`), &got)
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, got.Code, true, false, ""); err != nil {
+		t.Errorf("[code] %v", err)
+	}
+	if err := checkVar(t, got.Note, true, false, ""); err != nil {
+		t.Errorf("[note] %v", err)
+	}
+	if err := checkVar(t, got.Age, true, true, 25); err != nil {
+		t.Errorf("[age] %v", err)
+	}
+
+	x, err = xml.Marshal(got)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(x), `<item code=""><age>25</age><note xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></note></item>`)
+
+	err = xml.Unmarshal([]byte(`<item><age>foo</age></item>`), &got)
+	assertEqualTerminateTest(t, err.Error(), `strconv.ParseInt: parsing "foo": invalid syntax`)
+}
@@ -0,0 +1,301 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type (
+	// pgArrayElem is an element of a parsed PostgreSQL array literal
+	pgArrayElem struct {
+		value    string        // the value of the element
+		null     bool          // if the element is NULL
+		children []pgArrayElem // the elements of a nested array
+		isArray  bool          // if the element is a nested array
+	}
+
+	// pgArrayParser parses PostgreSQL array literals
+	pgArrayParser struct {
+		s   string
+		pos int
+	}
+)
+
+var (
+	nullVarType = reflect.TypeOf((*nullVar)(nil)).Elem()
+
+	errMalformedArray = errors.New("malformed array literal")
+)
+
+// isPGArrayType returns if values of the given type can be
+// encoded as and decoded from PostgreSQL array literals
+func isPGArrayType(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+
+	return isPGArrayElemType(t.Elem())
+}
+
+// isPGArrayElemType returns if the given type can be an element of a PostgreSQL array literal
+func isPGArrayElemType(t reflect.Type) bool {
+	if t.Implements(nullVarType) {
+		return isPGArrayElemType(reflect.Zero(t).Interface().(nullVar).valueType())
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer:
+		return isPGArrayElemType(t.Elem())
+	case reflect.Slice:
+		return isPGArrayType(t)
+	}
+
+	return false
+}
+
+// encodePGArray encodes the given slice as a PostgreSQL array literal
+func encodePGArray(rv reflect.Value) string {
+	var b strings.Builder
+	writePGArray(&b, rv)
+	return b.String()
+}
+
+// writePGArray writes the given slice as a PostgreSQL array literal
+func writePGArray(b *strings.Builder, rv reflect.Value) {
+	b.WriteByte('{')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writePGArrayElem(b, rv.Index(i))
+	}
+	b.WriteByte('}')
+}
+
+// writePGArrayElem writes a single element of a PostgreSQL array literal
+func writePGArrayElem(b *strings.Builder, rv reflect.Value) {
+	if nv, ok := rv.Interface().(nullVar); ok {
+		val := nv.getVal()
+		if val == nil {
+			b.WriteString("NULL")
+			return
+		}
+		rv = reflect.ValueOf(val)
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			b.WriteString("NULL")
+			return
+		}
+		writePGArrayElem(b, rv.Elem())
+	case reflect.Slice:
+		writePGArray(b, rv)
+	case reflect.String:
+		writePGArrayString(b, rv.String())
+	default:
+		b.WriteString(asString(rv.Interface()))
+	}
+}
+
+// writePGArrayString writes a string element, quoting it if necessary
+func writePGArrayString(b *strings.Builder, s string) {
+	needsQuote := s == "" || strings.EqualFold(s, "NULL")
+	for _, r := range s {
+		if strings.ContainsRune(`{}",\`, r) || unicode.IsSpace(r) {
+			needsQuote = true
+			break
+		}
+	}
+
+	if !needsQuote {
+		b.WriteString(s)
+		return
+	}
+
+	b.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+}
+
+// isPGArrayLiteral returns if src looks like a PostgreSQL array literal
+func isPGArrayLiteral(src any) (string, bool) {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return "", false
+	}
+
+	t := strings.TrimSpace(s)
+	return t, strings.HasPrefix(t, "{") || (strings.HasPrefix(t, "[") && strings.Contains(t, "={"))
+}
+
+// scanPGArray decodes the PostgreSQL array literal s into the slice dv
+func scanPGArray(dv reflect.Value, s string) error {
+	// skip the optional dimension decoration, e.g. [1:2]={1,2}
+	if strings.HasPrefix(s, "[") {
+		s = s[strings.Index(s, "=")+1:]
+	}
+
+	p := &pgArrayParser{s: s}
+	elem, err := p.parseArray()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return errMalformedArray
+	}
+
+	return assignPGArray(dv, elem.children)
+}
+
+// assignPGArray assigns the parsed elements to the slice dv
+func assignPGArray(dv reflect.Value, elems []pgArrayElem) error {
+	slice := reflect.MakeSlice(dv.Type(), len(elems), len(elems))
+
+	for i, e := range elems {
+		ev := slice.Index(i)
+
+		switch {
+		case e.isArray:
+			if ev.Kind() != reflect.Slice {
+				return fmt.Errorf("converting array literal: unexpected nested array for %s", ev.Type())
+			}
+			if err := assignPGArray(ev, e.children); err != nil {
+				return err
+			}
+		case e.null:
+			if np, ok := ev.Addr().Interface().(nullVarPtr); ok {
+				np.SetNil()
+				continue
+			}
+			if ev.Kind() != reflect.Pointer {
+				return fmt.Errorf("converting NULL array element to %s is unsupported", ev.Type())
+			}
+		default:
+			if err := convertAssign(ev.Addr().Interface(), e.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	dv.Set(slice)
+	return nil
+}
+
+// parseArray parses an array starting with {
+func (p *pgArrayParser) parseArray() (pgArrayElem, error) {
+	arr := pgArrayElem{isArray: true, children: []pgArrayElem{}}
+
+	p.skipSpace()
+	if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+		return arr, errMalformedArray
+	}
+	p.pos++
+
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return arr, nil
+	}
+
+	for {
+		elem, err := p.parseElem()
+		if err != nil {
+			return arr, err
+		}
+		arr.children = append(arr.children, elem)
+
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			return arr, errMalformedArray
+		}
+
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return arr, nil
+		default:
+			return arr, errMalformedArray
+		}
+	}
+}
+
+// parseElem parses a single element, which can be a nested array,
+// a quoted string or an unquoted value
+func (p *pgArrayParser) parseElem() (pgArrayElem, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return pgArrayElem{}, errMalformedArray
+	}
+
+	switch p.s[p.pos] {
+	case '{':
+		return p.parseArray()
+	case '"':
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.s) {
+			c := p.s[p.pos]
+			switch c {
+			case '\\':
+				p.pos++
+				if p.pos >= len(p.s) {
+					return pgArrayElem{}, errMalformedArray
+				}
+				b.WriteByte(p.s[p.pos])
+			case '"':
+				p.pos++
+				return pgArrayElem{value: b.String()}, nil
+			default:
+				b.WriteByte(c)
+			}
+			p.pos++
+		}
+		return pgArrayElem{}, errMalformedArray
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != '}' {
+		if p.s[p.pos] == '{' || p.s[p.pos] == '"' {
+			return pgArrayElem{}, errMalformedArray
+		}
+		p.pos++
+	}
+
+	value := strings.TrimSpace(p.s[start:p.pos])
+	if value == "" {
+		return pgArrayElem{}, errMalformedArray
+	}
+
+	return pgArrayElem{value: value, null: strings.EqualFold(value, "NULL")}, nil
+}
+
+// skipSpace skips whitespace characters
+func (p *pgArrayParser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
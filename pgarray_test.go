@@ -0,0 +1,105 @@
+package null
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+func TestPGArrayValue(t *testing.T) {
+	tests := []struct {
+		v    any
+		want any
+	}{
+		{From([]int64{1, 2, 3}), "{1,2,3}"},
+		{From([]int64{}), "{}"},
+		{From([]float64{1.5, -2}), "{1.5,-2}"},
+		{From([]bool{true, false}), "{true,false}"},
+		{From([]string{"a", "", "b c", `q"uote`, `back\slash`, "NULL", "{}", "a,b"}), `{a,"","b c","q\"uote","back\\slash","NULL","{}","a,b"}`},
+		{From([][]int{{1, 2}, {3, 4}}), "{{1,2},{3,4}}"},
+		{From([]Var[int]{From(1), Null[int](), Unset[int]()}), "{1,NULL,NULL}"},
+		{From([]*string{nil}), "{NULL}"},
+		{From([]byte("bytes")), []byte("bytes")},
+		{Null[[]int64](), nil},
+	}
+
+	for n, tc := range tests {
+		got, err := tc.v.(driver.Valuer).Value()
+		if err != nil {
+			t.Errorf("testCase #%v: %v", n, err)
+			continue
+		}
+
+		if fmt.Sprintf("%v", got) != fmt.Sprintf("%v", tc.want) {
+			t.Errorf("testCase #%v: got: %v != want: %v", n, got, tc.want)
+		}
+	}
+}
+
+func TestPGArrayScan(t *testing.T) {
+	var ints Var[[]int64]
+	err := ints.Scan([]byte("{1, 2 ,3}"))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", ints.Val()), "[1 2 3]")
+
+	err = ints.Scan("[0:2]={4,5,6}")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", ints.Val()), "[4 5 6]")
+
+	err = ints.Scan("{}")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, len(ints.Val()), 0)
+
+	var strs Var[[]string]
+	err = strs.Scan(`{a,"","b c","q\"uote","back\\slash","NULL","{}","a,b"}`)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%q", strs.Val()), `["a" "" "b c" "q\"uote" "back\\slash" "NULL" "{}" "a,b"]`)
+
+	var bools Var[[]bool]
+	err = bools.Scan("{t,f,true}")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", bools.Val()), "[true false true]")
+
+	var matrix Var[[][]float64]
+	err = matrix.Scan("{{1.5,2},{3,4}}")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", matrix.Val()), "[[1.5 2] [3 4]]")
+
+	var nullable Var[[]Var[int]]
+	err = nullable.Scan("{1,NULL,null}")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, len(nullable.Val()), 3)
+	if err := checkVar(t, nullable.Val()[0], true, true, 1); err != nil {
+		t.Errorf("[element 0] %v", err)
+	}
+	if err := checkVar(t, nullable.Val()[1], true, false, 0); err != nil {
+		t.Errorf("[element 1] %v", err)
+	}
+
+	var ptrs Var[[]*int]
+	err = ptrs.Scan("{NULL,2}")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, ptrs.Val()[0] == nil, true)
+	assertEqualTerminateTest(t, *ptrs.Val()[1], 2)
+
+	// round trip
+	v, _ := From([]string{"a b", `"`, "NULL"}).Value()
+	err = strs.Scan(v)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, fmt.Sprintf("%q", strs.Val()), `["a b" "\"" "NULL"]`)
+
+	// errors
+	err = ints.Scan("{1,NULL}")
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("{1,NULL}") to a []int64: converting NULL array element to int64 is unsupported`)
+
+	err = ints.Scan("{1,{2}}")
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("{1,{2}}") to a []int64: converting array literal: unexpected nested array for int64`)
+
+	for _, s := range []string{"{1,2", "{1,,2}", `{"a}`, "{1}x", "{a b\"}"} {
+		err = strs.Scan(s)
+		assertEqualTerminateTest(t, err.Error(), fmt.Sprintf("converting driver.Value type string (%q) to a []string: malformed array literal", s))
+	}
+
+	err = ints.Scan("{a}")
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("{a}") to a []int64: converting driver.Value type string ("a") to a int64: invalid syntax`)
+}
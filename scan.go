@@ -0,0 +1,118 @@
+package null
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ScanRow scans the current row of rows into dest, which must be a pointer to a struct.
+// Columns are matched to fields by the db tag, which can be changed with the UseTag option.
+// Nullable fields without a column in the result set are unset, while NULL
+// columns set them to NULL. Columns without a matching field are discarded.
+func ScanRow(rows *sql.Rows, dest any, opts ...option) error {
+	if rows == nil {
+		return errors.New("rows cannot be nil")
+	}
+
+	dv, err := scanDest(dest)
+	if err != nil {
+		return err
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fOpts, err := newOpts(defaultDBOpts, opts)
+	if err != nil {
+		return err
+	}
+
+	return scanRow(fOpts.tag, columns, rows, dv)
+}
+
+// ScanAll scans every remaining row of rows into a slice of T, which must be a struct.
+// Fields are matched the same way as in ScanRow.
+func ScanAll[T any](rows *sql.Rows, opts ...option) ([]T, error) {
+	if rows == nil {
+		return nil, errors.New("rows cannot be nil")
+	}
+
+	var def T
+	if reflect.TypeOf(def).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. T must be a struct", def)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	fOpts, err := newOpts(defaultDBOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []T{}
+	for rows.Next() {
+		var row T
+		if err := scanRow(fOpts.tag, columns, rows, reflect.ValueOf(&row).Elem()); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scanDest checks that dest is a non-nil pointer to a struct and returns the struct
+func scanDest(dest any) (reflect.Value, error) {
+	if dest == nil {
+		return reflect.Value{}, errors.New("destination cannot be nil")
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("invalid type %T. destination must be a non-nil pointer to a struct", dest)
+	}
+
+	return dv.Elem(), nil
+}
+
+// scanRow scans the current row into the given struct
+func scanRow(tag string, columns []string, rows *sql.Rows, dest reflect.Value) error {
+	fields := fieldsByName(tag, dest)
+
+	targets := make([]any, len(columns))
+	scanned := make(map[string]bool, len(columns))
+	for i, c := range columns {
+		f, ok := fields[c]
+		if !ok {
+			targets[i] = new(any)
+			continue
+		}
+
+		targets[i] = f.Addr().Interface()
+		scanned[c] = true
+	}
+
+	// nullable fields without a column are unset
+	for name, f := range fields {
+		if scanned[name] {
+			continue
+		}
+
+		if np, ok := f.Addr().Interface().(nullVarPtr); ok {
+			np.Unset()
+		}
+	}
+
+	return rows.Scan(targets...)
+}
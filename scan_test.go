@@ -0,0 +1,232 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeResult is a result set returned by fakeDriver for a given query
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDriver is an in-memory driver.Driver that returns predefined results for queries
+type fakeDriver struct {
+	mu      sync.Mutex
+	results map[string]fakeResult
+}
+
+type (
+	fakeConn struct{ d *fakeDriver }
+	fakeStmt struct {
+		d     *fakeDriver
+		query string
+	}
+	fakeRows struct {
+		result fakeResult
+		pos    int
+	}
+)
+
+var testDriver = &fakeDriver{results: map[string]fakeResult{}}
+
+func init() {
+	sql.Register("nullfake", testDriver)
+}
+
+// openFakeDB returns a database that returns result for the given query
+func openFakeDB(t testing.TB, query string, result fakeResult) *sql.DB {
+	t.Helper()
+
+	testDriver.mu.Lock()
+	testDriver.results[query] = result
+	testDriver.mu.Unlock()
+
+	db, err := sql.Open("nullfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	result, ok := s.d.results[s.query]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", s.query)
+	}
+	return &fakeRows{result: result}, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func TestScanRow(t *testing.T) {
+	type Base struct {
+		Filterable
+
+		ID int64 `db:"id"`
+	}
+
+	type Row struct {
+		Base
+
+		Name      Var[string]    `db:"name"`
+		Age       Var[int32]     `db:"age"`
+		CreatedAt Var[time.Time] `db:"created_at"`
+		Missing   Var[string]    `db:"missing"`
+		Custom    Var[string]    `custom_tag:"name"`
+	}
+
+	now := time.Unix(1672531261, 0)
+	db := openFakeDB(t, "scan row", fakeResult{
+		columns: []string{"id", "name", "age", "created_at", "unknown"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Peter"), int64(25), now, "?"},
+			{int64(2), nil, nil, nil, nil},
+		},
+	})
+
+	err := ScanRow(nil, &Row{})
+	assertEqualTerminateTest(t, err.Error(), "rows cannot be nil")
+
+	rows, err := db.Query("scan row")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	assertEqualTerminateTest(t, rows.Next(), true)
+
+	err = ScanRow(rows, Row{})
+	assertEqualTerminateTest(t, err.Error(), "invalid type null.Row. destination must be a non-nil pointer to a struct")
+
+	r := Row{Missing: From("stale")}
+	err = ScanRow(rows, &r)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, r.ID, int64(1))
+	if err := checkVar(t, r.Name, true, true, "Peter"); err != nil {
+		t.Errorf("[name] %v", err)
+	}
+	if err := checkVar(t, r.Age, true, true, 25); err != nil {
+		t.Errorf("[age] %v", err)
+	}
+	assertEqualTerminateTest(t, r.CreatedAt.Val().Equal(now), true)
+	if err := checkVar(t, r.Missing, false, false, ""); err != nil {
+		t.Errorf("[missing] %v", err)
+	}
+
+	assertEqualTerminateTest(t, rows.Next(), true)
+	err = ScanRow(rows, &r, UseTag("custom_tag"))
+	assertEqualTerminateTest(t, err == nil, true)
+	if err := checkVar(t, r.Custom, true, false, ""); err != nil {
+		t.Errorf("[custom] %v", err)
+	}
+
+	err = ScanRow(rows, &r, UseSetOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+}
+
+func TestScanAll(t *testing.T) {
+	type Base struct {
+		Filterable
+
+		ID int64 `db:"id"`
+	}
+
+	type Row struct {
+		Base
+
+		Name      Var[string]    `db:"name"`
+		Age       Var[int32]     `db:"age"`
+		CreatedAt Var[time.Time] `db:"created_at"`
+	}
+
+	db := openFakeDB(t, "scan all", fakeResult{
+		columns: []string{"id", "name", "age"},
+		rows: [][]driver.Value{
+			{int64(1), "Peter", int64(25)},
+			{int64(2), "Anna", nil},
+			{int64(3), "Bob", "x"},
+		},
+	})
+
+	_, err := ScanAll[Row](nil)
+	assertEqualTerminateTest(t, err.Error(), "rows cannot be nil")
+
+	rows, err := db.Query("scan all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ScanAll[int](rows)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. T must be a struct")
+
+	_, err = ScanAll[Row](rows, UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+
+	_, err = ScanAll[Row](rows)
+	assertEqualTerminateTest(t, err.Error(), `sql: Scan error on column index 2, name "age": converting driver.Value type string ("x") to a int32: invalid syntax`)
+	rows.Close()
+
+	db = openFakeDB(t, "scan all valid", fakeResult{
+		columns: []string{"id", "name", "age"},
+		rows: [][]driver.Value{
+			{int64(1), "Peter", int64(25)},
+			{int64(2), "Anna", nil},
+		},
+	})
+
+	rows, err = db.Query("scan all valid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	result, err := ScanAll[Row](rows)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, len(result), 2)
+	assertEqualTerminateTest(t, result[0].ID, int64(1))
+	if err := checkVar(t, result[0].Age, true, true, 25); err != nil {
+		t.Errorf("[age] %v", err)
+	}
+	if err := checkVar(t, result[1].Age, true, false, 0); err != nil {
+		t.Errorf("[age] %v", err)
+	}
+	if err := checkVar(t, result[1].CreatedAt, false, false, time.Time{}); err != nil {
+		t.Errorf("[created_at] %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package null
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+var (
+	textNull atomic.Pointer[string]
+)
+
+func init() {
+	SetTextNull("")
+}
+
+// SetTextNull sets the token that represents NULL in MarshalText and UnmarshalText.
+// The default is an empty string
+func SetTextNull(token string) {
+	textNull.Store(&token)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// Unset and NULL values are encoded as the NULL token set by SetTextNull.
+// The value is encoded by its own MarshalText method if it has one,
+// otherwise basic kinds are formatted with the strconv package
+func (v Var[T]) MarshalText() ([]byte, error) {
+	if v.state != StateValue {
+		return []byte(*textNull.Load()), nil
+	}
+
+	if tm, ok := any(v.value).(encoding.TextMarshaler); ok {
+		return tm.MarshalText()
+	}
+
+	switch reflect.ValueOf(v.value).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return []byte(asString(v.value)), nil
+	case reflect.Slice:
+		if b, ok := any(v.value).([]byte); ok {
+			return cloneBytes(b), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported MarshalText, type %T has no text representation", v.value)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The NULL token set by SetTextNull sets the value to NULL. The value is
+// decoded by its own UnmarshalText method if it has one, otherwise
+// it is converted with the same rules as Scan
+func (v *Var[T]) UnmarshalText(text []byte) error {
+	if string(text) == *textNull.Load() {
+		v.SetNil()
+		return nil
+	}
+
+	var value T
+	if tu, ok := any(&value).(encoding.TextUnmarshaler); ok {
+		if err := tu.UnmarshalText(text); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	}
+
+	if err := convertAssign(&value, string(text)); err != nil {
+		return err
+	}
+	v.Set(value)
+	return nil
+}
@@ -0,0 +1,119 @@
+package null
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+	"time"
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		v    interface{ MarshalText() ([]byte, error) }
+		want string
+	}{
+		{From("foo"), "foo"},
+		{From(int64(-42)), "-42"},
+		{From(uint8(42)), "42"},
+		{From(1.5), "1.5"},
+		{From(true), "true"},
+		{From([]byte("bytes")), "bytes"},
+		{From(customDefinedString("custom")), "custom"},
+		{From(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), "2023-01-02T03:04:05Z"},
+		{Null[string](), ""},
+		{Unset[int](), ""},
+	}
+
+	for n, tc := range tests {
+		got, err := tc.v.MarshalText()
+		if err != nil {
+			t.Errorf("testCase #%v: %v", n, err)
+			continue
+		}
+		assertEqualTerminateTest(t, string(got), tc.want)
+	}
+
+	_, err := From(customDefinedStruct{}).MarshalText()
+	assertEqualTerminateTest(t, err.Error(), "unsupported MarshalText, type null.customDefinedStruct has no text representation")
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var s Var[string]
+	assertEqualTerminateTest(t, s.UnmarshalText([]byte("foo")), nil)
+	if err := checkVar(t, s, true, true, "foo"); err != nil {
+		t.Errorf("[string] %v", err)
+	}
+
+	assertEqualTerminateTest(t, s.UnmarshalText([]byte("")), nil)
+	if err := checkVar(t, s, true, false, ""); err != nil {
+		t.Errorf("[null] %v", err)
+	}
+
+	var i Var[int16]
+	assertEqualTerminateTest(t, i.UnmarshalText([]byte("-42")), nil)
+	if err := checkVar(t, i, true, true, -42); err != nil {
+		t.Errorf("[int16] %v", err)
+	}
+
+	err := i.UnmarshalText([]byte("foo"))
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("foo") to a int16: invalid syntax`)
+
+	var b Var[bool]
+	assertEqualTerminateTest(t, b.UnmarshalText([]byte("true")), nil)
+	if err := checkVar(t, b, true, true, true); err != nil {
+		t.Errorf("[bool] %v", err)
+	}
+
+	var tm Var[time.Time]
+	assertEqualTerminateTest(t, tm.UnmarshalText([]byte("2023-01-02T03:04:05Z")), nil)
+	assertEqualTerminateTest(t, tm.Val().Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), true)
+
+	err = tm.UnmarshalText([]byte("foo"))
+	assertEqualTerminateTest(t, err.Error(), `parsing time "foo" as "2006-01-02T15:04:05Z07:00": cannot parse "foo" as "2006"`)
+}
+
+func TestSetTextNull(t *testing.T) {
+	defer SetTextNull("")
+	SetTextNull(`\N`)
+
+	got, err := Null[string]().MarshalText()
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(got), `\N`)
+
+	var s Var[string]
+	assertEqualTerminateTest(t, s.UnmarshalText([]byte("")), nil)
+	if err := checkVar(t, s, true, true, ""); err != nil {
+		t.Errorf("[empty] %v", err)
+	}
+
+	assertEqualTerminateTest(t, s.UnmarshalText([]byte(`\N`)), nil)
+	if err := checkVar(t, s, true, false, ""); err != nil {
+		t.Errorf("[null] %v", err)
+	}
+}
+
+func TestTextInterop(t *testing.T) {
+	// map keys in JSON
+	m := map[Var[int]]string{From(1): "one"}
+	j, err := json.Marshal(m)
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, string(j), `{"1":"one"}`)
+
+	var m2 map[Var[int]]string
+	assertEqualTerminateTest(t, json.Unmarshal(j, &m2), nil)
+	assertEqualTerminateTest(t, m2[From(1)], "one")
+
+	// flag.TextVar
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var timeout, retries Var[int]
+	fs.TextVar(&timeout, "timeout", Unset[int](), "")
+	fs.TextVar(&retries, "retries", Unset[int](), "")
+	assertEqualTerminateTest(t, fs.Parse([]string{"-timeout", "30"}), nil)
+
+	if err := checkVar(t, timeout, true, true, 30); err != nil {
+		t.Errorf("[timeout] %v", err)
+	}
+	if err := checkVar(t, retries, false, false, 0); err != nil {
+		t.Errorf("[retries] %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// timeConfig holds the settings of parsing textual timestamps
+	timeConfig struct {
+		layouts  []string
+		location *time.Location
+	}
+)
+
+var (
+	// DefaultTimeLayouts are the layouts used to parse textual timestamps during Scan
+	DefaultTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999-07",
+		"2006-01-02T15:04:05.999999999-07",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04",
+		time.DateOnly,
+	}
+
+	timeSettings atomic.Pointer[timeConfig]
+)
+
+func init() {
+	timeSettings.Store(&timeConfig{
+		layouts:  DefaultTimeLayouts,
+		location: time.UTC,
+	})
+}
+
+// SetTimeLayouts sets the layouts used to parse textual timestamps into
+// time.Time during Scan. They are tried in the given order. Calling it
+// without layouts restores DefaultTimeLayouts.
+func SetTimeLayouts(layouts ...string) {
+	if len(layouts) == 0 {
+		layouts = DefaultTimeLayouts
+	}
+
+	cfg := *timeSettings.Load()
+	cfg.layouts = append([]string{}, layouts...)
+	timeSettings.Store(&cfg)
+}
+
+// SetTimeLocation sets the location of parsed timestamps that don't contain
+// time zone information and of Unix epoch values. The default is UTC.
+// A nil location restores the default.
+func SetTimeLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	cfg := *timeSettings.Load()
+	cfg.location = loc
+	timeSettings.Store(&cfg)
+}
+
+// asTime converts textual timestamps and Unix epoch seconds into time.Time.
+// ok reports if src has a type that can be converted
+func asTime(src any) (t time.Time, ok bool, err error) {
+	cfg := timeSettings.Load()
+
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		rv := reflect.ValueOf(src)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return time.Unix(rv.Int(), 0).In(cfg.location), true, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return time.Unix(int64(rv.Uint()), 0).In(cfg.location), true, nil
+		case reflect.Float32, reflect.Float64:
+			sec, frac := math.Modf(rv.Float())
+			return time.Unix(int64(sec), int64(frac*float64(time.Second))).In(cfg.location), true, nil
+		}
+		return time.Time{}, false, nil
+	}
+
+	s = strings.TrimSpace(s)
+	for _, layout := range cfg.layouts {
+		t, err := time.ParseInLocation(layout, s, cfg.location)
+		if err == nil {
+			return t, true, nil
+		}
+	}
+
+	return time.Time{}, true, fmt.Errorf("converting driver.Value type %T (%q) to a time.Time: unsupported format", src, s)
+}
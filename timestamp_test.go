@@ -0,0 +1,76 @@
+package null
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScanTimestamp(t *testing.T) {
+	utc := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	nano := time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	plusOne := time.Date(2023, 1, 2, 4, 4, 5, 0, time.FixedZone("", 3600))
+
+	tests := []struct {
+		src  any
+		want time.Time
+	}{
+		{"2023-01-02T03:04:05Z", utc},
+		{"2023-01-02T03:04:05.123456789Z", nano},
+		{"2023-01-02T04:04:05+01:00", plusOne},
+		{"2023-01-02 04:04:05+01:00", plusOne},
+		{"2023-01-02 04:04:05+01", plusOne},
+		{"2023-01-02 03:04:05", utc},
+		{"2023-01-02 03:04:05.123456789", nano},
+		{[]byte("2023-01-02T03:04:05"), utc},
+		{"2023-01-02 03:04", utc.Add(-5 * time.Second)},
+		{"2023-01-02", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{utc.Unix(), utc},
+		{int32(utc.Unix()), utc},
+		{float64(utc.Unix()) + 0.5, utc.Add(500 * time.Millisecond)},
+	}
+
+	for n, tc := range tests {
+		var v Var[time.Time]
+		if err := v.Scan(tc.src); err != nil {
+			t.Errorf("testCase #%v: %v", n, err)
+			continue
+		}
+
+		if !v.Val().Equal(tc.want) {
+			t.Errorf("testCase #%v: got: %v != want: %v", n, v.Val(), tc.want)
+		}
+	}
+
+	var v Var[time.Time]
+	err := v.Scan("foo")
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("foo") to a time.Time: unsupported format`)
+
+	err = v.Scan(true)
+	assertEqualTerminateTest(t, err.Error(), "unsupported Scan, storing driver.Value type bool into type *time.Time")
+}
+
+func TestSetTimeLayouts(t *testing.T) {
+	defer SetTimeLayouts()
+	defer SetTimeLocation(nil)
+
+	loc := time.FixedZone("test", -3600)
+	SetTimeLayouts("02/01/2006 15:04")
+	SetTimeLocation(loc)
+
+	var v Var[time.Time]
+	err := v.Scan("2023-01-02")
+	assertEqualTerminateTest(t, err.Error(), `converting driver.Value type string ("2023-01-02") to a time.Time: unsupported format`)
+
+	err = v.Scan("02/01/2023 03:04")
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.Val().Equal(time.Date(2023, 1, 2, 3, 4, 0, 0, loc)), true)
+	assertEqualTerminateTest(t, v.Val().Location(), loc)
+
+	err = v.Scan(int64(0))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, v.Val().Location(), loc)
+
+	SetTimeLayouts()
+	err = v.Scan("2023-01-02")
+	assertEqualTerminateTest(t, err == nil, true)
+}
@@ -0,0 +1,61 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// BuildUpdate builds an UPDATE statement for the given table that only sets
+// the columns of the set nullable fields. Column names are taken from the db
+// tag, which can be changed with the UseTag option. The placeholder style and
+// identifier quoting can be set with the UseDialect option.
+//
+// The where clause uses ? placeholders for whereArgs, which are rewritten to
+// the style of the dialect. With named placeholders (DialectOracle) the where
+// clause and whereArgs are used as they are. An empty where clause updates every row.
+//
+// The returned arguments are the nullable variables themselves, so that
+// Var.Value handles NULL values.
+func BuildUpdate(table string, s any, where string, whereArgs []any, opts ...option) (string, []any, error) {
+	if s == nil {
+		return "", nil, errors.New("input cannot be nil")
+	}
+
+	rt := reflect.TypeOf(s)
+	if rt.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("invalid type %T. input must be a struct", s)
+	}
+
+	fOpts, err := newOpts(defaultSQLBuilderOpts, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := sqlColumns(fOpts.tag, reflect.ValueOf(s))
+	if len(columns) == 0 {
+		return "", nil, errors.New("no columns to update")
+	}
+
+	b := newSQLBuilder(fOpts.dialect)
+	b.write("UPDATE ")
+	b.ident(table)
+	b.write(" SET ")
+	for i, c := range columns {
+		if i > 0 {
+			b.write(", ")
+		}
+		b.ident(c.name)
+		b.write(" = ")
+		b.bind(c.name, c.value)
+	}
+
+	if where != "" {
+		b.write(" WHERE ")
+		if err := b.clause(where, whereArgs); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return b.String(), b.args, nil
+}
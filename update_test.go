@@ -0,0 +1,95 @@
+package null
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func TestBuildUpdate(t *testing.T) {
+	type Audit struct {
+		Filterable
+
+		UpdatedBy Var[string] `db:"updated_by"`
+		Name      Var[string] `db:"name"`
+	}
+
+	type S struct {
+		Name    Var[string] `db:"name"`
+		Age     Var[int64]  `db:"age"`
+		Email   Var[string] `db:"e-mail" json:"email"`
+		Skipped Var[string] `db:"-"`
+		NoTag   Var[string]
+		Other   string `json:"other"`
+		Audit
+	}
+
+	_, _, err := BuildUpdate("users", nil, "", nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+
+	_, _, err = BuildUpdate("users", int64(1), "", nil)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int64. input must be a struct")
+
+	_, _, err = BuildUpdate("users", S{}, "", nil)
+	assertEqualTerminateTest(t, err.Error(), "no columns to update")
+
+	s := S{
+		Name:    From("Peter"),
+		Email:   Null[string](),
+		Skipped: From("skipped"),
+		NoTag:   From("no tag"),
+		Audit: Audit{
+			UpdatedBy: From("admin"),
+			Name:      From("ignored"),
+		},
+	}
+
+	_, _, err = BuildUpdate("users", s, "id = ?", nil)
+	assertEqualTerminateTest(t, err.Error(), `not enough arguments for clause "id = ?"`)
+
+	_, _, err = BuildUpdate("users", s, "id = ?", []any{1, 2})
+	assertEqualTerminateTest(t, err.Error(), `too many arguments for clause "id = ?"`)
+
+	_, _, err = BuildUpdate("users", s, "id = ?", []any{1}, UseSetOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+
+	tests := []struct {
+		dialect Dialect
+		query   string
+	}{
+		{DialectDefault, `UPDATE users SET name = ?, e-mail = ?, updated_by = ? WHERE id = ? AND note <> '?'`},
+		{DialectMySQL, "UPDATE `users` SET `name` = ?, `e-mail` = ?, `updated_by` = ? WHERE id = ? AND note <> '?'"},
+		{DialectPostgres, `UPDATE "users" SET "name" = $1, "e-mail" = $2, "updated_by" = $3 WHERE id = $4 AND note <> '?'`},
+		{DialectSQLServer, `UPDATE [users] SET [name] = @p1, [e-mail] = @p2, [updated_by] = @p3 WHERE id = @p4 AND note <> '?'`},
+	}
+
+	for n, tc := range tests {
+		query, args, err := BuildUpdate("users", s, "id = ? AND note <> '?'", []any{42}, UseDialect(tc.dialect))
+		if err != nil {
+			t.Errorf("testCase #%v: %v", n, err)
+			continue
+		}
+
+		assertEqualTerminateTest(t, query, tc.query)
+		assertEqualTerminateTest(t, fmt.Sprintf("%+v", args), fmt.Sprintf("%+v", []any{s.Name, s.Email, s.UpdatedBy, 42}))
+	}
+
+	query, args, err := BuildUpdate("app.users", s, "id = :id", []any{sql.Named("id", 42)}, UseDialect(DialectOracle))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `UPDATE "app"."users" SET "name" = :name, "e-mail" = :e_mail, "updated_by" = :updated_by WHERE id = :id`)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", args), fmt.Sprintf("%+v", []any{
+		sql.Named("name", s.Name),
+		sql.Named("e_mail", s.Email),
+		sql.Named("updated_by", s.UpdatedBy),
+		sql.Named("id", 42),
+	}))
+
+	// the arguments handle NULL values through Var.Value
+	v, _ := args[1].(sql.NamedArg).Value.(Var[string]).Value()
+	assertEqualTerminateTest(t, v == nil, true)
+
+	query, args, err = BuildUpdate("users", s, "", nil, UseTag("json"))
+	assertEqualTerminateTest(t, err == nil, true)
+	assertEqualTerminateTest(t, query, `UPDATE users SET email = ?, other = ?`)
+	assertEqualTerminateTest(t, fmt.Sprintf("%+v", args), fmt.Sprintf("%+v", []any{s.Email, ""}))
+}
@@ -0,0 +1,203 @@
+package null
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+)
+
+type valuesOpts struct {
+	filterOpts
+	nullValues []string // strings that mean NULL
+}
+
+// defaultValuesOpts are the options of the URL query value helpers.
+// Unlike the other helpers they use the form tag by default
+var defaultValuesOpts = valuesOpts{
+	filterOpts: filterOpts{tag: "form"},
+	nullValues: []string{""},
+}
+
+// UseNullValues sets the strings that mean NULL in URL query values and
+// environment variables. The default is an empty string. Calling it without
+// values turns off NULL handling, so every present value is parsed as a value
+func UseNullValues(values ...string) optFunc[valuesOpts] {
+	values = append([]string{}, values...)
+
+	return func(o *valuesOpts) {
+		o.nullValues = values
+	}
+}
+
+// DecodeValues decodes the given URL query or form values into dest, which must be a pointer to a struct.
+// Parameters are matched to fields by the form tag, which can be changed with the UseTag option, e.g. to query.
+//
+// Nullable fields of missing parameters are unset, while parameters holding one of the
+// strings set with UseNullValues set them to NULL. Other values are converted with the same
+// rules as Scan. Slice fields take every value of the parameter, other fields take the first one.
+func DecodeValues(values url.Values, dest any, opts ...option) error {
+	dv, err := scanDest(dest)
+	if err != nil {
+		return err
+	}
+
+	fOpts, err := newOpts(defaultValuesOpts, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range flatFields(fOpts.tag, dv) {
+		vals, ok := values[f.name]
+		if !ok || len(vals) == 0 {
+			if np, ok := f.value.Addr().Interface().(nullVarPtr); ok {
+				np.Unset()
+			}
+			continue
+		}
+
+		if err := decodeValues(f.value, vals, fOpts.nullValues); err != nil {
+			return fmt.Errorf("parameter %q: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// EncodeValues encodes the given struct or pointer to a struct into URL query values.
+// Parameters are named by the form tag, which can be changed with the UseTag option.
+//
+// Unset fields and nil pointers are left out, while NULL fields are encoded as the
+// first string set with UseNullValues. Slices are encoded as repeated parameters.
+func EncodeValues(s any, opts ...option) (url.Values, error) {
+	if s == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	sv := reflect.Indirect(reflect.ValueOf(s))
+	if sv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %T. input must be a struct", s)
+	}
+
+	fOpts, err := newOpts(defaultValuesOpts, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	for _, f := range flatFields(fOpts.tag, sv) {
+		value := f.value.Interface()
+		if nv, ok := value.(nullVar); ok {
+			if !nv.isSet() {
+				continue
+			}
+
+			value = nv.getVal()
+			if value == nil {
+				if len(fOpts.nullValues) > 0 {
+					values.Set(f.name, fOpts.nullValues[0])
+				}
+				continue
+			}
+		}
+
+		vals, err := encodeValues(reflect.ValueOf(value))
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", f.name, err)
+		}
+		if vals != nil {
+			values[f.name] = vals
+		}
+	}
+
+	return values, nil
+}
+
+// decodeValues converts the given parameter values and stores them in the field
+func decodeValues(fv reflect.Value, vals []string, nullValues []string) error {
+	dest := fv.Addr().Interface()
+
+	valueType := fv.Type()
+	nv, isNullVar := fv.Interface().(nullVar)
+	if isNullVar {
+		valueType = nv.valueType()
+	}
+
+	nullable := isNullVar || fv.Kind() == reflect.Pointer
+	if nullable && len(vals) == 1 && slices.Contains(nullValues, vals[0]) {
+		return convertAssign(dest, nil)
+	}
+
+	if valueType.Kind() != reflect.Slice || valueType.Elem().Kind() == reflect.Uint8 {
+		return convertAssign(dest, vals[0])
+	}
+
+	sv := reflect.MakeSlice(valueType, len(vals), len(vals))
+	for i, v := range vals {
+		if err := convertAssign(sv.Index(i).Addr().Interface(), v); err != nil {
+			return err
+		}
+	}
+
+	if isNullVar {
+		return dest.(nullVarPtr).assign(sv.Interface())
+	}
+
+	fv.Set(sv)
+	return nil
+}
+
+// encodeValues formats the given value as parameter values.
+// It returns nil for nil pointers
+func encodeValues(rv reflect.Value) ([]string, error) {
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		vals := make([]string, rv.Len())
+		for i := range vals {
+			v, err := encodeValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = v
+		}
+		return vals, nil
+	}
+
+	v, err := encodeValue(rv)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{v}, nil
+}
+
+// encodeValue formats a single value by its MarshalText method if it has one,
+// otherwise basic kinds are formatted with the strconv package
+func encodeValue(rv reflect.Value) (string, error) {
+	if tm, ok := rv.Interface().(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return asString(rv.Interface()), nil
+	case reflect.Slice:
+		if b, ok := rv.Interface().([]byte); ok {
+			return string(b), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported type %s", rv.Type())
+}
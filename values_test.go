@@ -0,0 +1,137 @@
+package null
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// ValuesTestPage is exported, so that its fields are promoted when embedded
+type ValuesTestPage struct {
+	Filterable
+
+	Limit  Var[int] `form:"limit" query:"l"`
+	Offset int      `form:"offset"`
+}
+
+type valuesTestFilter struct {
+	Filterable
+	ValuesTestPage
+
+	Name    Var[string]    `form:"name" query:"n"`
+	Age     Var[int]       `form:"age"`
+	Active  Var[bool]      `form:"active"`
+	IDs     Var[[]int64]   `form:"id"`
+	Tags    []string       `form:"tag"`
+	Since   Var[time.Time] `form:"since"`
+	Score   *float64       `form:"score"`
+	Ignored string         `form:"-"`
+}
+
+func TestDecodeValues(t *testing.T) {
+	values, err := url.ParseQuery("name=foo&age=&id=1&id=2&tag=a&tag=b&since=2023-01-02&score=1.5&limit=10&offset=20&unknown=x")
+	assertEqualTerminateTest(t, err, nil)
+
+	f := valuesTestFilter{Active: From(true)}
+	assertEqualTerminateTest(t, DecodeValues(values, &f), nil)
+	assertEqualTerminateTest(t, f.Name, From("foo"))
+	assertEqualTerminateTest(t, f.Age, Null[int]())
+	assertEqualTerminateTest(t, f.Active, Unset[bool]())
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", f.IDs.Val()), "[1 2]")
+	assertEqualTerminateTest(t, fmt.Sprintf("%v", f.Tags), "[a b]")
+	assertEqualTerminateTest(t, f.Since.Val().Equal(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)), true)
+	assertEqualTerminateTest(t, *f.Score, 1.5)
+	assertEqualTerminateTest(t, f.Limit, From(10))
+	assertEqualTerminateTest(t, f.Offset, 20)
+
+	// custom NULL strings
+	values, _ = url.ParseQuery("name=&age=null&score=null&id=null")
+	f = valuesTestFilter{}
+	assertEqualTerminateTest(t, DecodeValues(values, &f, UseNullValues("null")), nil)
+	assertEqualTerminateTest(t, f.Name, From(""))
+	assertEqualTerminateTest(t, f.Age, Null[int]())
+	assertEqualTerminateTest(t, f.Score, nil)
+	assertEqualTerminateTest(t, f.IDs.State(), StateNull)
+
+	// without NULL strings every present parameter is a value
+	values, _ = url.ParseQuery("name=")
+	f = valuesTestFilter{}
+	assertEqualTerminateTest(t, DecodeValues(values, &f, UseNullValues()), nil)
+	assertEqualTerminateTest(t, f.Name, From(""))
+
+	// custom tag
+	values, _ = url.ParseQuery("n=foo&l=5&name=bar")
+	f = valuesTestFilter{}
+	assertEqualTerminateTest(t, DecodeValues(values, &f, UseTag("query")), nil)
+	assertEqualTerminateTest(t, f.Name, From("foo"))
+	assertEqualTerminateTest(t, f.Limit, From(5))
+
+	values, _ = url.ParseQuery("age=foo")
+	err = DecodeValues(values, &f)
+	assertEqualTerminateTest(t, err.Error(), `parameter "age": converting driver.Value type string ("foo") to a int: invalid syntax`)
+
+	values, _ = url.ParseQuery("id=1&id=x")
+	err = DecodeValues(values, &f)
+	assertEqualTerminateTest(t, err.Error(), `parameter "id": converting driver.Value type string ("x") to a int64: invalid syntax`)
+
+	err = DecodeValues(values, f)
+	assertEqualTerminateTest(t, err.Error(), "invalid type null.valuesTestFilter. destination must be a non-nil pointer to a struct")
+
+	err = DecodeValues(values, &f, UseDialect(DialectPostgres))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 0")
+}
+
+func TestEncodeValues(t *testing.T) {
+	score := 1.5
+	f := valuesTestFilter{
+		ValuesTestPage: ValuesTestPage{Limit: From(10), Offset: 20},
+		Name:           From("foo bar"),
+		Age:            Null[int](),
+		IDs:            From([]int64{1, 2}),
+		Tags:           []string{"a", "b"},
+		Since:          From(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)),
+		Score:          &score,
+		Ignored:        "ignored",
+	}
+
+	values, err := EncodeValues(f)
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, values.Encode(), "age=&id=1&id=2&limit=10&name=foo+bar&offset=20&score=1.5&since=2023-01-02T00%3A00%3A00Z&tag=a&tag=b")
+
+	f = valuesTestFilter{Age: Null[int](), Name: From("foo")}
+	values, err = EncodeValues(&f, UseNullValues("null", ""), UseTag("query"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, values.Encode(), "n=foo")
+
+	values, err = EncodeValues(&f, UseNullValues("null"))
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, values.Encode(), "age=null&name=foo&offset=0")
+
+	values, err = EncodeValues(&f, UseNullValues())
+	assertEqualTerminateTest(t, err, nil)
+	assertEqualTerminateTest(t, values.Encode(), "name=foo&offset=0")
+
+	_, err = EncodeValues(&f, UseTag("query"), UseSetOp(PatchOpAdd))
+	assertEqualTerminateTest(t, err.Error(), "unsupported option at index 1")
+
+	// round trip
+	var decoded valuesTestFilter
+	values, _ = EncodeValues(valuesTestFilter{Name: From(""), Age: Null[int]()}, UseNullValues("null"))
+	assertEqualTerminateTest(t, DecodeValues(values, &decoded, UseNullValues("null")), nil)
+	assertEqualTerminateTest(t, decoded.Name, From(""))
+	assertEqualTerminateTest(t, decoded.Age, Null[int]())
+	assertEqualTerminateTest(t, decoded.Active, Unset[bool]())
+
+	type S struct {
+		V Var[map[string]int] `form:"v"`
+	}
+	_, err = EncodeValues(S{V: From(map[string]int{})})
+	assertEqualTerminateTest(t, err.Error(), `parameter "v": unsupported type map[string]int`)
+
+	_, err = EncodeValues(1)
+	assertEqualTerminateTest(t, err.Error(), "invalid type int. input must be a struct")
+
+	_, err = EncodeValues(nil)
+	assertEqualTerminateTest(t, err.Error(), "input cannot be nil")
+}
@@ -0,0 +1,72 @@
+package null
+
+import (
+	"encoding/xml"
+)
+
+const (
+	// xsiNamespace is the namespace of the xsi:nil attribute
+	xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// MarshalXML implements the xml.Marshaler interface.
+// Unset values are omitted, NULL values are encoded as an empty element with
+// the xsi:nil="true" attribute and every other value is encoded as the element's content
+func (v Var[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch v.state {
+	case StateUnset:
+		return nil
+	case StateNull:
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	return e.EncodeElement(v.value, start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+// Elements with the xsi:nil="true" attribute set the value to NULL
+func (v *Var[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") &&
+			(attr.Value == "true" || attr.Value == "1") {
+			v.SetNil()
+			return d.Skip()
+		}
+	}
+
+	var value T
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+
+	v.Set(value)
+	return nil
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface.
+// Unset values are omitted, while every other value is encoded the same way as in MarshalText
+func (v Var[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if v.state == StateUnset {
+		return xml.Attr{}, nil
+	}
+
+	text, err := v.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+// The attribute is decoded the same way as in UnmarshalText
+func (v *Var[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return v.UnmarshalText([]byte(attr.Value))
+}